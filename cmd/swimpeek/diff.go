@@ -0,0 +1,84 @@
+package swimpeek
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/internal/lanediff"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+)
+
+// cmdDiff compares two dumps of the same tenant.
+func cmdDiff(args []string) {
+	format := lanediff.FormatText
+	outfile := ""
+	flagSet := flag.NewFlagSet("diff", flag.ExitOnError)
+	flagSet.StringVar(&format, "format", format, "Output format: "+strings.Join(lanediff.Formats, ", "))
+	flagSet.StringVar(&outfile, "outfile", "", "Write the report to this file (default: standard output)")
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek diff [options] <old-dump> <new-dump>")
+		fmt.Println("Reports the playbooks, components, applications, fields, connectors, sensors, and orchestration tasks that were added, removed, or modified.")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+	if flagSet.NArg() != 2 {
+		flagSet.Usage()
+		os.Exit(1)
+	}
+	if !slices.Contains(lanediff.Formats, format) {
+		logger.Fatal("Invalid format", "format", format, "expected", strings.Join(lanediff.Formats, ", "))
+	}
+
+	oldState, err := lanedump.LoadFromDisk(flagSet.Arg(0))
+	if err != nil {
+		logger.Fatal("Failed to load dump file", "error", err)
+	}
+	newState, err := lanedump.LoadFromDisk(flagSet.Arg(1))
+	if err != nil {
+		logger.Fatal("Failed to load dump file", "error", err)
+	}
+
+	// Resources are matched by their tenant-local ids, which only line up within a tenant
+	if oldState.Tenant.Id != newState.Tenant.Id {
+		logger.Warn("The dumps are of different tenants, resources are matched by id and will mostly show as added and removed", "old", oldState.Tenant.Name, "new", newState.Tenant.Name)
+	}
+	if oldState.TimeStamp.After(newState.TimeStamp) {
+		logger.Warn("The old dump is newer than the new dump, the changes are reversed", "old", flagSet.Arg(0), "new", flagSet.Arg(1))
+	}
+	for _, state := range []*lanedump.LaneState{oldState, newState} {
+		if missing := state.MissingResources(); len(missing) > 0 {
+			logger.Warn("The dump is incomplete, missing data shows as removed or added", "tenant", state.Tenant.Name, "missing", strings.Join(missing, ", "))
+		}
+	}
+
+	if err := writeReport(lanediff.DiffStates(oldState, newState), format, outfile); err != nil {
+		logger.Fatal("Failed to write report", "error", err)
+	}
+}
+
+// writeReport writes a diff report to the output file, or to standard output if no file is given.
+// The output file is closed before returning, so a failed write or close is reported instead of leaving a truncated report behind silently.
+func writeReport(diff lanediff.StateDiff, format string, outfile string) error {
+	if outfile == "" {
+		return diff.WriteReport(os.Stdout, format)
+	}
+
+	file, err := os.Create(outfile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := diff.WriteReport(file, format); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outfile, err)
+	}
+	logger.Info("Report written", "outfile", outfile)
+	return nil
+}
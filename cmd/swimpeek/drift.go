@@ -0,0 +1,60 @@
+package swimpeek
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/internal/lanediff"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+)
+
+// cmdDrift compares the content of two tenants, resources are matched by Uid.
+func cmdDrift(args []string) {
+	format := lanediff.FormatText
+	outfile := ""
+	flagSet := flag.NewFlagSet("drift", flag.ExitOnError)
+	flagSet.StringVar(&format, "format", format, "Output format: "+strings.Join(lanediff.Formats, ", "))
+	flagSet.StringVar(&outfile, "outfile", "", "Write the report to this file (default: standard output)")
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek drift [options] <dump> <other-dump>")
+		fmt.Println("Reports content missing on either tenant, version mismatches, and workflow differences, e.g. between dev.json and prod.json.")
+		fmt.Println("Playbooks, components, applications, and orchestration tasks are matched by uid, connectors and sensors by name.")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+	if flagSet.NArg() != 2 {
+		flagSet.Usage()
+		os.Exit(1)
+	}
+	if !slices.Contains(lanediff.Formats, format) {
+		logger.Fatal("Invalid format", "format", format, "expected", strings.Join(lanediff.Formats, ", "))
+	}
+
+	states := make([]*lanedump.LaneState, 0, 2)
+	for _, path := range flagSet.Args() {
+		laneState, err := lanedump.LoadFromDisk(path)
+		if err != nil {
+			logger.Fatal("Failed to load dump file", "error", err)
+		}
+		if missing := laneState.MissingResources(); len(missing) > 0 {
+			logger.Warn("The dump is incomplete, missing data shows as only present in the other tenant", "tenant", laneState.Tenant.Name, "missing", strings.Join(missing, ", "))
+		}
+		states = append(states, laneState)
+	}
+	if states[0].Tenant.Id == states[1].Tenant.Id {
+		logger.Warn("Both dumps are of the same tenant, use diff to see what changed between them", "tenant", states[0].Tenant.Name)
+	}
+
+	diff, warns := lanediff.DriftStates(states[0], states[1])
+	for _, warn := range warns {
+		logger.Warn(warn)
+	}
+	if err := writeReport(diff, format, outfile); err != nil {
+		logger.Fatal("Failed to write report", "error", err)
+	}
+}
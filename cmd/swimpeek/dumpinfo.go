@@ -0,0 +1,109 @@
+package swimpeek
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+)
+
+// cmdDumpInfo prints the header and a summary of a dump without loading it.
+func cmdDumpInfo(args []string) {
+	flagSet := flag.NewFlagSet("dump-info", flag.ExitOnError)
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek dump-info <dump>")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+	if flagSet.NArg() != 1 {
+		flagSet.Usage()
+		os.Exit(1)
+	}
+
+	info, err := lanedump.ReadInfo(flagSet.Arg(0))
+	if err != nil {
+		logger.Fatal("Failed to read dump", "error", err)
+	}
+	header := info.Header
+
+	printFn := func(label string, value any) {
+		fmt.Printf("%-18s %v\n", label, value)
+	}
+	unknownFn := func(value string) string {
+		if value == "" {
+			return "unknown"
+		}
+		return value
+	}
+
+	schema := fmt.Sprintf("%d", header.SchemaVersion)
+	switch {
+	case header.SchemaVersion < lanedump.CurrentSchemaVersion:
+		schema += fmt.Sprintf(" (migrated to %d on load)", lanedump.CurrentSchemaVersion)
+	case header.SchemaVersion > lanedump.CurrentSchemaVersion:
+		schema += fmt.Sprintf(" (newer than supported version %d, upgrade SwimPeek)", lanedump.CurrentSchemaVersion)
+	}
+	if header.MigratedFrom != nil {
+		schema += fmt.Sprintf(" (migrated from %d)", *header.MigratedFrom)
+	}
+
+	printFn("Schema version", schema)
+	printFn("SwimPeek version", unknownFn(header.SwimPeekVersion))
+	printFn("Turbine version", unknownFn(header.TurbineVersion))
+	printFn("Compression", info.Compression)
+	printFn("Created", info.TimeStamp.Format(time.DateTime))
+	printFn("Tenant", fmt.Sprintf("%s (%s)", info.Tenant.Name, info.Tenant.Id))
+	if info.Profile != "" {
+		printFn("Profile", info.Profile)
+	}
+	if !info.RunsSince.IsZero() {
+		printFn("Run history since", info.RunsSince.Format(time.DateTime))
+	}
+	if info.Refresh != nil {
+		printFn("Incremental", fmt.Sprintf("base from %s, %d solutions changed, %d workflows and %d applications refreshed",
+			info.Refresh.BaseTimeStamp.Format(time.DateTime), len(info.Refresh.ChangedSolutionIds),
+			len(info.Refresh.RefreshedWorkflowIds), len(info.Refresh.RefreshedApplicationIds)))
+	}
+
+	if header.Redaction != nil {
+		reasons := make(map[string]int)
+		for _, redaction := range header.Redaction.Redactions {
+			reasons[redaction.Reason]++
+		}
+		counts := make([]string, 0, len(reasons))
+		for _, reason := range slices.Sorted(maps.Keys(reasons)) {
+			counts = append(counts, fmt.Sprintf("%s: %d", reason, reasons[reason]))
+		}
+		printFn("Redacted", fmt.Sprintf("%d values (%s)", len(header.Redaction.Redactions), strings.Join(counts, ", ")))
+	}
+
+	fmt.Println("\nResources:")
+	for _, member := range slices.Sorted(maps.Keys(info.Counts)) {
+		fmt.Printf("  %-24s %d\n", member, info.Counts[member])
+	}
+
+	if len(info.FetchErrors) > 0 {
+		fmt.Println("\nMissing data (best-effort dump):")
+		for _, resource := range slices.Sorted(maps.Keys(info.FetchErrors)) {
+			fmt.Printf("  %-24s %s\n", resource, info.FetchErrors[resource])
+		}
+	}
+
+	if len(header.FetchDurations) > 0 {
+		fmt.Println("\nFetch durations:")
+		resources := slices.SortedFunc(maps.Keys(header.FetchDurations), func(a, b string) int {
+			return cmp.Compare(header.FetchDurations[b], header.FetchDurations[a])
+		})
+		for _, resource := range resources {
+			fmt.Printf("  %-24s %s\n", resource, header.FetchDurations[resource].Round(time.Millisecond))
+		}
+	}
+}
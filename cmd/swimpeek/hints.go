@@ -0,0 +1,71 @@
+package swimpeek
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// permissionAreas maps API path fragments to a human-readable permission area, more specific fragments come first.
+var permissionAreas = []struct {
+	fragment string
+	area     string
+}{
+	{"/solution-builder/import", "solution import"},
+	{"/solution-builder/", "playbook and component (solution builder) read"},
+	{"/orchestration/", "orchestration read"},
+	{"/tenants", "account-level tenant listing"},
+	{"/orchestrationtask", "orchestration task read"},
+	{"/app", "application read"},
+}
+
+// apiErrorHint returns an actionable explanation for API errors, or an empty string if there's nothing to add.
+func apiErrorHint(err error) string {
+	apiErr, ok := laneclient.AsAPIError(err)
+	if !ok {
+		return ""
+	}
+
+	switch {
+	case laneclient.IsUnauthorized(err):
+		return "the credentials were rejected, the access token may be expired or revoked; run 'swimpeek config' to update it or check SWIMPEEK_TOKEN"
+	case laneclient.IsForbidden(err):
+		area := permissionArea(apiErr.URL)
+		if apiErr.Method == http.MethodPut {
+			area = strings.TrimSuffix(area, " read") + " write"
+		}
+		return fmt.Sprintf("token lacks %s permission; check the roles assigned to the token owner", area)
+	case laneclient.IsNotFound(err):
+		return "endpoint not found; check the region, base URL and account ID in the configuration"
+	case laneclient.IsRateLimited(err):
+		return "the API is rate limiting requests; try again later or increase -retries and -retry-delay"
+	case apiErr.StatusCode >= 500:
+		return "the Swimlane API reported a server error; try again later"
+	}
+	return ""
+}
+
+// permissionArea derives the permission area from the URL of a failed request.
+func permissionArea(rawURL string) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+	}
+	for _, pa := range permissionAreas {
+		if strings.Contains(path, pa.fragment) {
+			return pa.area
+		}
+	}
+	return "the required"
+}
+
+// fatalWithHint logs a fatal error, adding a hint when the error was caused by the API.
+func fatalWithHint(msg string, err error) {
+	if hint := apiErrorHint(err); hint != "" {
+		logger.Fatal(msg, "error", err, "hint", hint)
+	}
+	logger.Fatal(msg, "error", err)
+}
@@ -0,0 +1,111 @@
+package swimpeek
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/internal/config"
+	"github.com/just-oblivious/swimpeek/internal/lanediff"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// cmdHistory shows the revision history of a playbook or component.
+func cmdHistory(args []string) {
+	tenantId := ""
+	profile := ""
+	dumpfile := ""
+	limit := 10
+	isComponent := false
+	flagSet := flag.NewFlagSet("history", flag.ExitOnError)
+	flagSet.StringVar(&profile, "profile", "", "Configuration profile to use (default: the profile of -dump, otherwise the active profile)")
+	flagSet.StringVar(&tenantId, "tenant", "", "Tenant ID (default: the tenant of -dump, otherwise a picker dialog will be shown)")
+	flagSet.StringVar(&dumpfile, "dump", "", "Store the revisions in this dump, they can then be browsed in the analyzer")
+	flagSet.IntVar(&limit, "limit", limit, "Maximum number of revisions to fetch (0 fetches all revisions)")
+	flagSet.BoolVar(&isComponent, "component", false, "The ID refers to a component instead of a playbook")
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek history [options] <playbook-id>")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+	if flagSet.NArg() != 1 {
+		flagSet.Usage()
+		os.Exit(1)
+	}
+	solutionId := flagSet.Arg(0)
+
+	kind := laneclient.SolutionPlaybook
+	if isComponent {
+		kind = laneclient.SolutionComponent
+	}
+
+	var laneState *lanedump.LaneState
+	if dumpfile != "" {
+		var err error
+		laneState, err = lanedump.LoadFromDisk(dumpfile)
+		if err != nil {
+			logger.Fatal("Failed to load dump file", "error", err)
+		}
+		if tenantId == "" {
+			tenantId = laneState.Tenant.Id
+		}
+	}
+
+	cfg, _ := loadConfigForDump(profile, laneState)
+	client := newLaneClient(cfg, config.GetLogger("laneclient"))
+	ctx := context.Background()
+
+	tenants, err := client.GetTenants(ctx)
+	if err != nil {
+		fatalWithHint("Failed to fetch tenants", err)
+	}
+	tenant, err := selectTenant(tenants.Tenants, tenantId)
+	if err != nil {
+		logger.Fatal("Failed to select tenant", "error", err)
+	}
+	tenantClient := laneclient.NewTenantClient(client, tenant)
+
+	revisions, err := lanedump.LoadSolutionHistory(ctx, &tenantClient, kind, solutionId, limit)
+	if err != nil {
+		fatalWithHint("Failed to load revision history", err)
+	}
+	if len(revisions) == 0 {
+		logger.Info("No revisions found", "id", solutionId)
+		return
+	}
+
+	// Print the changes of each revision compared to the revision before it, the oldest revision is the baseline
+	for idx, rev := range revisions {
+		fmt.Printf("v%d  %s  %s  %s\n", rev.Version, rev.ModifiedDate.Format(time.DateTime), rev.ModifiedBy.Name, rev.Comment)
+		if idx == len(revisions)-1 {
+			fmt.Println("    (oldest fetched revision)")
+			continue
+		}
+		diffs := lanediff.DiffRevisions(revisions[idx+1], rev)
+		if len(diffs) == 0 {
+			fmt.Println("    no workflow changes")
+		}
+		for _, diff := range diffs {
+			fmt.Printf("  ▶ %s\n", diff.Title)
+			for _, line := range diff.Summary() {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+
+	if laneState != nil {
+		if laneState.HistoryBySolutionId == nil {
+			laneState.HistoryBySolutionId = make(map[string][]lanedump.SolutionRevision)
+		}
+		laneState.HistoryBySolutionId[solutionId] = revisions
+		if err := lanedump.WriteToDisk(laneState, dumpfile); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Revisions stored in dump", "outfile", dumpfile, "revisions", len(revisions))
+	}
+}
@@ -0,0 +1,65 @@
+package swimpeek
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/just-oblivious/swimpeek/internal/config"
+)
+
+// cmdConfigList lists the configuration profiles, the active profile is marked with *.
+func cmdConfigList() {
+	cfgDir, err := config.GetConfigDir(false)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	profiles, err := config.ListProfiles(cfgDir)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if len(profiles) == 0 {
+		logger.Warn("No profiles found, run 'swimpeek config' to create one.")
+		return
+	}
+
+	active, err := config.ResolveProfile(cfgDir, "")
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	for _, profile := range profiles {
+		marker := " "
+		if profile == active {
+			marker = "*"
+		}
+
+		cfg, err := config.ReadConfig(cfgDir, profile)
+		if err != nil {
+			fmt.Printf("%s %-16s (unreadable: %s)\n", marker, profile, err)
+			continue
+		}
+		fmt.Printf("%s %-16s %-32s account %s\n", marker, profile, cfg.BaseURL(), cfg.SwimlaneAccountId)
+	}
+}
+
+// cmdConfigUse selects the profile used when no -profile flag is given.
+func cmdConfigUse(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: swimpeek config use <profile>")
+		os.Exit(1)
+	}
+
+	cfgDir, err := config.GetConfigDir(false)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	if err := config.SetActiveProfile(cfgDir, args[0]); err != nil {
+		logger.Fatal("Failed to select profile", "error", err)
+	}
+	logger.Info("Active profile changed", "profile", args[0])
+	if env := os.Getenv(config.EnvProfile); env != "" {
+		logger.Warn(fmt.Sprintf("%s is set and takes precedence", config.EnvProfile), "profile", env)
+	}
+}
@@ -0,0 +1,237 @@
+package swimpeek
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/config"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/internal/picker"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// bundleDumpPath returns the path of the lanedump stored next to a solution bundle.
+func bundleDumpPath(bundlePath string) string {
+	return strings.TrimSuffix(bundlePath, filepath.Ext(bundlePath)) + ".lanedump.json"
+}
+
+// cmdExportSolution exports a playbook or component as a solution package.
+func cmdExportSolution(args []string) {
+	tenantId := ""
+	profile := ""
+	infile := ""
+	outfile := ""
+	flagSet := flag.NewFlagSet("export-solution", flag.ExitOnError)
+	flagSet.StringVar(&profile, "profile", "", "Configuration profile to use (default: the profile of -infile, otherwise the active profile)")
+	flagSet.StringVar(&tenantId, "tenant", "", "Tenant ID to export from (if not specified, a picker dialog will be shown)")
+	flagSet.StringVar(&infile, "infile", "", "Dump of the source tenant, used to store the solution content next to the bundle for checking before import")
+	flagSet.StringVar(&outfile, "outfile", "", "Output file for the bundle (default: solution_{id}.zip)")
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek export-solution [options] <playbook|component> <id>")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+	if flagSet.NArg() != 2 {
+		flagSet.Usage()
+		os.Exit(1)
+	}
+
+	kind, solutionId := flagSet.Arg(0), flagSet.Arg(1)
+	var solutionKind laneclient.SolutionKind
+	switch kind {
+	case "playbook":
+		solutionKind = laneclient.SolutionPlaybook
+	case "component":
+		solutionKind = laneclient.SolutionComponent
+	default:
+		logger.Fatal("Invalid solution kind, expected 'playbook' or 'component'", "kind", kind)
+	}
+
+	if outfile == "" {
+		outfile = fmt.Sprintf("solution_%s.zip", solutionId)
+	}
+
+	// Extract the solution content from the source dump first, this catches typos in the ID before calling the API
+	var bundleState, sourceState *lanedump.LaneState
+	if infile != "" {
+		laneState, err := lanedump.LoadFromDisk(infile)
+		if err != nil {
+			logger.Fatal("Failed to load dump file", "error", err)
+		}
+		g, _, err := graph.FromState(laneState)
+		if err != nil {
+			logger.Fatal("Failed to create graph from lane state", "error", err)
+		}
+
+		resources := g.Resources.PlaybooksById
+		if solutionKind == laneclient.SolutionComponent {
+			resources = g.Resources.ComponentsById
+		}
+		rootNode, exists := resources[solutionId]
+		if !exists {
+			logger.Fatal("Solution not found in dump", "kind", kind, "id", solutionId, "infile", infile)
+		}
+
+		a := analyzer.NewAnalyzer(laneState, g)
+		bundleState = a.ExtractSolution(a.SolutionDependencies(rootNode))
+		sourceState = laneState
+		if tenantId == "" {
+			tenantId = laneState.Tenant.Id
+		}
+	} else {
+		logger.Warn("No source dump specified, the bundle can't be checked before import (use -infile)")
+	}
+
+	cfg, _ := loadConfigForDump(profile, sourceState)
+	client := newLaneClient(cfg, config.GetLogger("laneclient"))
+	ctx := context.Background()
+
+	tenants, err := client.GetTenants(ctx)
+	if err != nil {
+		fatalWithHint("Failed to fetch tenants", err)
+	}
+	tenant, err := selectTenant(tenants.Tenants, tenantId)
+	if err != nil {
+		logger.Fatal("Failed to select tenant", "error", err)
+	}
+	tenantClient := laneclient.NewTenantClient(client, tenant)
+
+	logger.Info("Exporting solution", "kind", kind, "id", solutionId, "tenant", tenant.Name)
+	pkg, err := tenantClient.ExportSolution(ctx, solutionKind, solutionId)
+	if err != nil {
+		fatalWithHint("Failed to export solution", err)
+	}
+	if err := os.WriteFile(outfile, pkg, 0o644); err != nil {
+		logger.Fatal("Failed to write bundle", "error", err)
+	}
+	logger.Info("Solution exported", "outfile", outfile, "size", len(pkg))
+
+	if bundleState != nil {
+		dumpPath := bundleDumpPath(outfile)
+		if err := lanedump.WriteToDisk(bundleState, dumpPath); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Solution content stored", "outfile", dumpPath,
+			"playbooks", len(bundleState.PlaybooksById),
+			"components", len(bundleState.ComponentsById),
+			"applications", len(bundleState.ApplicationsById),
+			"connectors", len(bundleState.ConnectorsById),
+			"assets", len(bundleState.AssetsById))
+	}
+}
+
+// cmdImportSolution imports a solution package, optionally checking it against a dump of the target tenant first.
+func cmdImportSolution(args []string) {
+	tenantId := ""
+	profile := ""
+	checkfile := ""
+	dryRun := false
+	assumeYes := false
+	force := false
+	flagSet := flag.NewFlagSet("import-solution", flag.ExitOnError)
+	flagSet.StringVar(&profile, "profile", "", "Configuration profile to use (default: the profile of -check, otherwise the active profile)")
+	flagSet.StringVar(&tenantId, "tenant", "", "Tenant ID to import into (if not specified, a picker dialog will be shown)")
+	flagSet.StringVar(&checkfile, "check", "", "Dump of the target tenant to check the bundle against before importing")
+	flagSet.BoolVar(&dryRun, "dry-run", false, "Only check the bundle, don't import it")
+	flagSet.BoolVar(&assumeYes, "yes", false, "Import without asking for confirmation")
+	flagSet.BoolVar(&force, "force", false, "Import even if the check reports errors")
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek import-solution [options] <bundle.zip>")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+	if flagSet.NArg() != 1 {
+		flagSet.Usage()
+		os.Exit(1)
+	}
+	bundlePath := flagSet.Arg(0)
+
+	pkg, err := os.ReadFile(bundlePath)
+	if err != nil {
+		logger.Fatal("Failed to read bundle", "error", err)
+	}
+
+	// Check the bundle content against the target tenant
+	var target *lanedump.LaneState
+	if checkfile != "" {
+		bundleState, err := lanedump.LoadFromDisk(bundleDumpPath(bundlePath))
+		if err != nil {
+			logger.Fatal("Failed to load the solution content stored next to the bundle, export it with -infile to enable checks", "error", err)
+		}
+		target, err = lanedump.LoadFromDisk(checkfile)
+		if err != nil {
+			logger.Fatal("Failed to load target dump", "error", err)
+		}
+		if tenantId == "" {
+			tenantId = target.Tenant.Id
+		}
+
+		errCount := 0
+		for _, issue := range analyzer.CheckSolutionBundle(bundleState, target) {
+			switch issue.Severity {
+			case analyzer.BundleError:
+				errCount++
+				logger.Error(issue.Message, "resource", issue.Resource())
+			case analyzer.BundleWarning:
+				logger.Warn(issue.Message, "resource", issue.Resource())
+			default:
+				logger.Info(issue.Message, "resource", issue.Resource())
+			}
+		}
+		logger.Info("Bundle checked", "target", target.Tenant.Name, "errors", errCount)
+		if errCount > 0 && !force && !dryRun {
+			logger.Fatal("Bundle check failed, fix the errors or use -force to import anyway")
+		}
+	} else if dryRun {
+		logger.Fatal("Nothing to do, specify a target dump with -check")
+	}
+
+	if dryRun {
+		logger.Info("Dry run, bundle not imported")
+		return
+	}
+
+	cfg, profile := loadConfigForDump(profile, target)
+	client := newLaneClient(cfg, config.GetLogger("laneclient"))
+	ctx := context.Background()
+
+	tenants, err := client.GetTenants(ctx)
+	if err != nil {
+		fatalWithHint("Failed to fetch tenants", err)
+	}
+	tenant, err := selectTenant(tenants.Tenants, tenantId)
+	if err != nil {
+		logger.Fatal("Failed to select tenant", "error", err)
+	}
+
+	if !assumeYes {
+		confirmed, err := picker.Confirm(
+			fmt.Sprintf("Import %s into tenant %s (%s)?", filepath.Base(bundlePath), tenant.Name, describeTarget(cfg, profile)),
+			"Existing playbooks and components with the same Uid will be overwritten.",
+		)
+		if err != nil {
+			logger.Fatal("Confirmation failed", "error", err)
+		}
+		if !confirmed {
+			logger.Info("Aborted, bundle not imported")
+			return
+		}
+	}
+
+	tenantClient := laneclient.NewTenantClient(client, tenant)
+	result, err := tenantClient.ImportSolution(ctx, filepath.Base(bundlePath), pkg)
+	if err != nil {
+		fatalWithHint("Failed to import solution", err)
+	}
+	logger.Info("Solution imported", "tenant", tenant.Name, "name", result.Name, "id", result.Id, "version", result.Version)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/just-oblivious/swimpeek/internal/lanedump"
 	"github.com/just-oblivious/swimpeek/internal/picker"
 	"github.com/just-oblivious/swimpeek/internal/tui"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
 	"github.com/just-oblivious/swimpeek/pkg/laneclient"
 
 	"github.com/charmbracelet/log"
@@ -23,9 +25,16 @@ var version string = "0.2"
 func printUsage() {
 	fmt.Println("Usage: swimpeek <command> [options]")
 	fmt.Println("Available commands:")
-	fmt.Println("  config   - Create or modify the SwimPeek configuration.")
+	fmt.Println("  config   - Create or modify the SwimPeek configuration, 'config list' and 'config use' manage profiles.")
 	fmt.Println("  dump     - Dump the tenant data to a file for analysis.")
 	fmt.Println("  analyze  - Analyze the dumped tenant data.")
+	fmt.Println("  dump-info - Show the header and a summary of a dump.")
+	fmt.Println("  diff     - Compare two dumps of the same tenant.")
+	fmt.Println("  drift    - Compare the content of two tenants, matched by uid.")
+	fmt.Println("  toggle   - Enable or disable a playbook, workflow, or orchestration task.")
+	fmt.Println("  history  - Show the revision history of a playbook or component.")
+	fmt.Println("  export-solution - Export a playbook or component as a solution package.")
+	fmt.Println("  import-solution - Check and import a solution package.")
 	fmt.Println("  version  - Show the SwimPeek version.")
 	fmt.Println("Run 'swimpeek <command> -help' for more information on a specific command.")
 }
@@ -36,7 +45,7 @@ func SwimPeek() {
 		switch cmd {
 
 		case "config":
-			cmdConfig()
+			cmdConfig(os.Args[2:])
 
 		case "dump":
 			cmdDump(os.Args[2:])
@@ -44,6 +53,27 @@ func SwimPeek() {
 		case "analyze":
 			cmdAnalyze(os.Args[2:])
 
+		case "toggle":
+			cmdToggle(os.Args[2:])
+
+		case "history":
+			cmdHistory(os.Args[2:])
+
+		case "dump-info":
+			cmdDumpInfo(os.Args[2:])
+
+		case "diff":
+			cmdDiff(os.Args[2:])
+
+		case "drift":
+			cmdDrift(os.Args[2:])
+
+		case "export-solution":
+			cmdExportSolution(os.Args[2:])
+
+		case "import-solution":
+			cmdImportSolution(os.Args[2:])
+
 		case "version":
 			logger.Info("swimpeek version: " + version)
 
@@ -57,20 +87,42 @@ func SwimPeek() {
 		}
 		return
 	}
-	logger.Fatal("Please specify a command. Available commands: config, dump, analyze, version.")
+	logger.Fatal("Please specify a command. Available commands: config, dump, analyze, dump-info, toggle, history, export-solution, import-solution, version.")
 }
 
-// cmdConfig creates or modifies the SwimPeek configuration.
-func cmdConfig() {
-	cfg := loadConfig(true)
+// cmdConfig creates or modifies the SwimPeek configuration of a profile, the list and use subcommands manage the profiles.
+func cmdConfig(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			cmdConfigList()
+			return
+		case "use":
+			cmdConfigUse(args[1:])
+			return
+		}
+	}
+
+	profile := ""
+	flagSet := flag.NewFlagSet("config", flag.ExitOnError)
+	flagSet.StringVar(&profile, "profile", "", "Profile to create or modify (default: the active profile)")
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek config [-profile <name>] | config list | config use <name>")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+
+	cfg, profile := loadConfig(true, profile)
 
 	// Test the connection to Swimlane
 	logger.Info("Testing connection to Swimlane...")
-	client := laneclient.NewLaneClient(cfg.FQDN(), cfg.SwimlaneAccountId, cfg.SwimlaneAccessToken, logger)
+	client := newLaneClient(cfg, logger)
 	ctx := context.Background()
 	tenants, err := client.GetTenants(ctx)
 	if err != nil {
-		logger.Fatal("Failed to connect", "error", err)
+		fatalWithHint("Failed to connect", err)
 	}
 	if len(tenants.Tenants) == 0 {
 		logger.Fatal("No tenants found for the account. Please check your configuration.")
@@ -80,30 +132,68 @@ func cmdConfig() {
 		logger.Print("Tenant found", "name", tenant.Name, "id", tenant.Id, "users", tenant.UserCount)
 	}
 
-	logger.Info("Configuration initialized 🎉")
+	logger.Info("Configuration initialized 🎉", "profile", profile)
 }
 
 // cmdDump dumps the tenant data to a file for use with the analyze command.
 func cmdDump(args []string) {
 	outfile := ""
 	tenantId := ""
+	profile := ""
+	baseFile := ""
+	format := "file"
+	pageSize := 0
+	redact := false
+	redactKeys := ""
+	redactOpts := lanedump.DefaultRedactOptions()
+	loadOpts := lanedump.LoadOptions{}
+	retryPolicy := laneclient.DefaultRetryPolicy()
 	flagSet := flag.NewFlagSet("dump", flag.ExitOnError)
+	flagSet.StringVar(&profile, "profile", "", "Configuration profile to use (default: the active profile)")
 	flagSet.StringVar(&tenantId, "tenant", "", "Tenant ID to dump (if not specified, a picker dialog will be shown)")
-	flagSet.StringVar(&outfile, "outfile", "", "Output file for the dump (default: lanedump_{tenant}.json)")
+	flagSet.StringVar(&outfile, "outfile", "", "Output file for the dump, use a .json.gz or .json.zst extension for a gzip or zstd compressed dump (default: lanedump_{tenant}.json, or lanedump_{tenant} for -format dir)")
+	flagSet.StringVar(&format, "format", format, "Dump format: 'file' for a single JSON file, 'dir' for a directory with one file per playbook, component, workflow, application, connector, and sensor")
+	flagSet.StringVar(&baseFile, "base", "", "Previous dump of the tenant, only workflows and applications that changed since are fetched")
+	flagSet.BoolVar(&loadOpts.WithIdentities, "with-identities", false, "Include account users, groups, and roles for access auditing")
+	flagSet.DurationVar(&loadOpts.RunsSince, "runs-since", 0, "Include run metrics for workflow runs in this period, e.g. 168h (default: no run history)")
+	flagSet.BoolVar(&loadOpts.BestEffort, "best-effort", false, "Keep going when a resource type can't be fetched, the dump records what is missing")
+	flagSet.BoolVar(&redact, "redact", false, "Replace secrets in action inputs, scripts, and sensor settings with placeholders, e.g. before sharing the dump")
+	flagSet.StringVar(&redactKeys, "redact-keys", "", "Comma-separated regular expressions for additional member names whose values are redacted")
+	flagSet.Float64Var(&redactOpts.MinEntropy, "redact-entropy", redactOpts.MinEntropy, "Redact tokens with at least this entropy in bits per character (0 disables the heuristic)")
+	flagSet.StringVar(&redactOpts.Salt, "redact-salt", "", "Secret for deriving placeholders, equal values get the same placeholder across dumps with the same salt (default: derived from the tenant ID, which is not secret)")
+	flagSet.IntVar(&pageSize, "page-size", 0, "Number of items to request per page (default: endpoint specific)")
+	flagSet.IntVar(&retryPolicy.MaxRetries, "retries", retryPolicy.MaxRetries, "Maximum number of retries for failed requests (0 disables retries)")
+	flagSet.DurationVar(&retryPolicy.BaseDelay, "retry-delay", retryPolicy.BaseDelay, "Initial delay between retries, doubled after each attempt")
+	flagSet.DurationVar(&retryPolicy.MaxDelay, "retry-max-delay", retryPolicy.MaxDelay, "Maximum delay between retries")
 	if err := flagSet.Parse(args); err != nil {
 		logger.Fatal("Failed to parse flags", "error", err)
 	}
+	if format != "file" && format != "dir" {
+		logger.Fatal("Invalid format, expected 'file' or 'dir'", "format", format)
+	}
 
-	cfg := loadConfig(false)
+	// Incremental dumps default to the tenant of the base dump
+	if baseFile != "" {
+		base, err := lanedump.LoadFromDisk(baseFile)
+		if err != nil {
+			logger.Fatal("Failed to load base dump", "error", err)
+		}
+		loadOpts.Base = base
+		if tenantId == "" {
+			tenantId = base.Tenant.Id
+		}
+	}
+
+	cfg, profile := loadConfig(false, profile)
 
 	// List the available tenants (implicitly testing the connection)
-	client := laneclient.NewLaneClient(cfg.FQDN(), cfg.SwimlaneAccountId, cfg.SwimlaneAccessToken, config.GetLogger("laneclient"))
+	client := newLaneClient(cfg, config.GetLogger("laneclient"), laneclient.WithRetryPolicy(retryPolicy), laneclient.WithPageSize(pageSize))
 	ctx := context.Background()
 
 	logger.Info("Fetching tenants...")
 	tenants, err := client.GetTenants(ctx)
 	if err != nil {
-		logger.Fatal(err)
+		fatalWithHint("Failed to fetch tenants", err)
 	}
 
 	tenant, err := selectTenant(tenants.Tenants, tenantId)
@@ -113,27 +203,52 @@ func cmdDump(args []string) {
 
 	// If no output file is specified, use the name of the selected tenant.
 	if outfile == "" {
-		outfile = fmt.Sprintf("lanedump_%s.json", strings.ToLower(strings.ReplaceAll(tenant.Name, " ", "_")))
+		outfile = fmt.Sprintf("lanedump_%s", strings.ToLower(strings.ReplaceAll(tenant.Name, " ", "_")))
+		if format == "file" {
+			outfile += ".json"
+		}
 	}
 
 	// Dump the tenant configuration to a file
-	logger.Info("Dumping tenant configuration", "tenant", tenant.Name, "id", tenant.Id)
+	logger.Info("Dumping tenant configuration", "tenant", tenant.Name, "id", tenant.Id, "profile", profile)
 	tenantClient := laneclient.NewTenantClient(client, tenant)
-	laneState, err := lanedump.LoadFromTenant(ctx, &tenantClient)
+	laneState, err := lanedump.LoadFromTenant(ctx, &tenantClient, loadOpts)
 	if err != nil {
-		logger.Fatal("Failed to dump tenant data", "error", err)
+		fatalWithHint("Failed to dump tenant data", err)
+	}
+	laneState.Profile = profile
+	laneState.Header.SwimPeekVersion = version
+	writeOpts := make([]func(*lanedump.WriteOptions), 0)
+	if format == "dir" {
+		writeOpts = append(writeOpts, lanedump.WithDirLayout())
+	}
+	if redact {
+		for _, pattern := range strings.Split(redactKeys, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				redactOpts.KeyPatterns = append(redactOpts.KeyPatterns, pattern)
+			}
+		}
+		writeOpts = append(writeOpts, lanedump.WithRedaction(redactOpts))
 	}
-	if err := lanedump.WriteToDisk(laneState, outfile); err != nil {
+	if err := lanedump.WriteToDisk(laneState, outfile, writeOpts...); err != nil {
 		logger.Fatal(err)
 	}
+	if missing := laneState.MissingResources(); len(missing) > 0 {
+		logger.Warn("Tenant dumped with missing data", "outfile", outfile, "missing", strings.Join(missing, ", "))
+		return
+	}
 	logger.Info("Tenant dumped successfully", "outfile", outfile)
 }
 
 // cmdAnalyze analyzes the dumped tenant data.
 func cmdAnalyze(args []string) {
 	infile := ""
+	profile := ""
+	writeAccess := false
 	flagSet := flag.NewFlagSet("analyze", flag.ExitOnError)
-	flagSet.StringVar(&infile, "infile", "", "Input file for the analysis")
+	flagSet.StringVar(&infile, "infile", "", "Input file or directory dump for the analysis, gzip and zstd compressed dumps are decompressed on the fly")
+	flagSet.StringVar(&profile, "profile", "", "Configuration profile used in write mode (default: the profile the dump was made with)")
+	flagSet.BoolVar(&writeAccess, "write", false, "Allow enabling and disabling workflows and orchestration tasks in the live tenant (opt-in write mode, press W in the analyzer)")
 	if err := flagSet.Parse(args); err != nil {
 		logger.Fatal("Failed to parse flags", "error", err)
 	}
@@ -148,6 +263,9 @@ func cmdAnalyze(args []string) {
 	if err != nil {
 		logger.Fatal("Failed to load dump file", "error", err)
 	}
+	for _, resource := range laneState.MissingResources() {
+		logger.Warn("The dump is incomplete, data is missing", "resource", resource, "error", laneState.FetchErrors[resource])
+	}
 
 	graph, warns, err := graph.FromState(laneState)
 	if err != nil {
@@ -157,15 +275,48 @@ func cmdAnalyze(args []string) {
 		logger.Warn(warn)
 	}
 
+	// Write mode changes the tenant the dump was taken from
+	var writer app.Writer
+	if writeAccess {
+		cfg, profile := loadConfigForDump(profile, laneState)
+		client := newLaneClient(cfg, config.GetLogger("laneclient"))
+		writer = tui.NewTenantWriter(laneclient.NewTenantClient(client, laneState.Tenant), laneState)
+		logger.Warn("Write access enabled, changes are applied to the live tenant", "tenant", laneState.Tenant.Name, "profile", profile)
+	}
+
 	// Launch the resource browser
-	if err := tui.LaunchExplorer(laneState, graph); err != nil {
+	if err := tui.LaunchExplorer(laneState, graph, writer); err != nil {
 		logger.Fatal("Failed to launch resource explorer", "error", err)
 	}
 }
 
-// loadConfig loads the SwimPeek configuration from the default location.
-// If newCfg is true, it initializes a new configuration.
-func loadConfig(newCfg bool) *config.Config {
+// loadConfigForDump loads the configuration for the tenant of a dump, the profile defaults to the one the dump was made with.
+// Without a dump this is loadConfig for an existing configuration.
+func loadConfigForDump(profile string, laneState *lanedump.LaneState) (*config.Config, string) {
+	if laneState == nil {
+		return loadConfig(false, profile)
+	}
+	if profile == "" {
+		profile = laneState.Profile
+	}
+	cfg, profile := loadConfig(false, profile)
+	if laneState.Profile != "" && laneState.Profile != profile {
+		logger.Warn("The dump was made with a different profile", "dump", laneState.Profile, "profile", profile)
+	}
+	return cfg, profile
+}
+
+// describeTarget describes the profile and region (or custom base URL) requests are sent to, e.g. for confirmation prompts.
+func describeTarget(cfg *config.Config, profile string) string {
+	if cfg.SwimlaneBaseURL != "" {
+		return fmt.Sprintf("profile %s, %s", profile, cfg.BaseURL())
+	}
+	return fmt.Sprintf("profile %s, region %s", profile, cfg.SwimlaneRegion)
+}
+
+// loadConfig loads the configuration of a profile from the default location, an empty profile selects the active profile.
+// If newCfg is true, it initializes a new configuration. The resolved profile name is returned with the configuration.
+func loadConfig(newCfg bool, profile string) (*config.Config, string) {
 	// Load the configuration
 	cfgDir, err := config.GetConfigDir(newCfg)
 	if err != nil {
@@ -174,27 +325,85 @@ func loadConfig(newCfg bool) *config.Config {
 		os.Exit(1)
 	}
 
+	profile, err = config.ResolveProfile(cfgDir, profile)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
 	if newCfg {
 		// Run the config initialization
-		cfg, err := config.InitConfig(cfgDir)
+		cfg, err := config.InitConfig(cfgDir, profile)
 		if err != nil {
 			logger.Fatal(err)
 		}
 
 		// Write the config
-		if err := config.SaveConfig(cfgDir, cfg); err != nil {
+		if err := config.SaveConfig(cfgDir, profile, cfg); err != nil {
 			logger.Fatal(err)
 		}
-		return cfg
+		return cfg, profile
 	}
 
-	cfg, err := config.ReadConfig(cfgDir)
+	cfg, err := config.ReadConfig(cfgDir, profile)
 	if err != nil {
-		logger.Fatal(err)
+		logger.Error(err)
+		logger.Fatal(fmt.Sprintf("Run 'swimpeek config -profile %s' to create the profile, or 'swimpeek config list' to show the existing profiles.", profile))
+	}
+
+	return cfg, profile
+}
+
+// newLaneClient creates an API client using the base URL, transport settings, and credentials from the configuration.
+func newLaneClient(cfg *config.Config, clientLogger *log.Logger, options ...func(*laneclient.LaneClient)) laneclient.LaneClient {
+	clientOptions := []func(*laneclient.LaneClient){
+		laneclient.WithBaseURL(cfg.BaseURL()),
+		laneclient.WithAuth(newAuthProvider(cfg)),
+	}
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			logger.Fatal("Invalid proxy URL in configuration", "proxy", cfg.ProxyURL, "error", err)
+		}
+		clientOptions = append(clientOptions, laneclient.WithProxy(proxy))
+	}
 
+	if cfg.CABundle != "" {
+		pool, err := laneclient.LoadCABundle(cfg.CABundle)
+		if err != nil {
+			logger.Fatal("Failed to load CA bundle", "error", err)
+		}
+		clientOptions = append(clientOptions, laneclient.WithRootCAs(pool))
 	}
 
-	return cfg
+	if cfg.InsecureSkipVerify {
+		logger.Warn("TLS certificate verification is disabled")
+		clientOptions = append(clientOptions, laneclient.WithInsecureSkipVerify(true))
+	}
+
+	return laneclient.NewLaneClient(cfg.FQDN(), cfg.SwimlaneAccountId, "", clientLogger, append(clientOptions, options...)...)
+}
+
+// newAuthProvider resolves the credentials from the environment or configuration, the password for the login flow is prompted for when not set.
+func newAuthProvider(cfg *config.Config) laneclient.AuthProvider {
+	creds, err := cfg.Credentials()
+	if err != nil {
+		logger.Fatal("Failed to load credentials", "error", err)
+	}
+
+	if !creds.IsLogin() {
+		logger.Debug("Using access token", "source", creds.Source)
+		return laneclient.NewTokenAuth(creds.Token)
+	}
+
+	if creds.Password == "" {
+		creds.Password, err = config.PromptPassword(creds.Username)
+		if err != nil {
+			logger.Fatal("Failed to read password", "error", err)
+		}
+	}
+	logger.Debug("Using login", "username", creds.Username, "source", creds.Source)
+	return laneclient.NewLoginAuth(creds.Username, creds.Password)
 }
 
 // selectTenant shows a tenant picker dialog and returns the selected tenant.
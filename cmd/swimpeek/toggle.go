@@ -0,0 +1,217 @@
+package swimpeek
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/just-oblivious/swimpeek/internal/config"
+	"github.com/just-oblivious/swimpeek/internal/picker"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// toggleChange is a planned change of the enabled state of a single resource.
+type toggleChange struct {
+	kind    string
+	id      string
+	name    string
+	enabled bool // Current state.
+	apply   func(ctx context.Context) error
+}
+
+// cmdToggle enables or disables playbooks, workflows, and orchestration tasks.
+func cmdToggle(args []string) {
+	tenantId := ""
+	profile := ""
+	dryRun := false
+	assumeYes := false
+	flagSet := flag.NewFlagSet("toggle", flag.ExitOnError)
+	flagSet.StringVar(&profile, "profile", "", "Configuration profile to use (default: the active profile)")
+	flagSet.StringVar(&tenantId, "tenant", "", "Tenant ID (if not specified, a picker dialog will be shown)")
+	flagSet.BoolVar(&dryRun, "dry-run", false, "Show the planned changes without applying them")
+	flagSet.BoolVar(&assumeYes, "yes", false, "Apply the changes without asking for confirmation")
+	flagSet.Usage = func() {
+		fmt.Println("Usage: swimpeek toggle [options] <on|off> <playbook|workflow|task> <id>")
+		fmt.Println("Enables or disables a playbook (all of its workflows), a single workflow, or an orchestration task (record event or playbook button).")
+		flagSet.PrintDefaults()
+	}
+	if err := flagSet.Parse(args); err != nil {
+		logger.Fatal("Failed to parse flags", "error", err)
+	}
+
+	if flagSet.NArg() != 3 {
+		flagSet.Usage()
+		os.Exit(1)
+	}
+
+	var enable bool
+	switch flagSet.Arg(0) {
+	case "on":
+		enable = true
+	case "off":
+		enable = false
+	default:
+		logger.Fatal("Invalid state, expected 'on' or 'off'", "state", flagSet.Arg(0))
+	}
+	kind, resourceId := flagSet.Arg(1), flagSet.Arg(2)
+
+	cfg, profile := loadConfig(false, profile)
+	client := newLaneClient(cfg, config.GetLogger("laneclient"))
+	ctx := context.Background()
+
+	tenants, err := client.GetTenants(ctx)
+	if err != nil {
+		fatalWithHint("Failed to fetch tenants", err)
+	}
+	tenant, err := selectTenant(tenants.Tenants, tenantId)
+	if err != nil {
+		logger.Fatal("Failed to select tenant", "error", err)
+	}
+	tenantClient := laneclient.NewTenantClient(client, tenant)
+	logger.Info("Planning changes", "tenant", tenant.Name, "target", describeTarget(cfg, profile))
+
+	changes, err := planToggle(ctx, tenantClient, kind, resourceId, enable)
+	if err != nil {
+		fatalWithHint("Failed to plan changes", err)
+	}
+
+	confirm := func(pending []toggleChange) (bool, error) {
+		return picker.Confirm(
+			fmt.Sprintf("Apply %d change(s) to tenant %s (%s)?", len(pending), tenant.Name, describeTarget(cfg, profile)),
+			fmt.Sprintf("The listed resources will be %s in the live tenant.", stateLabel(enable)),
+		)
+	}
+	if assumeYes {
+		confirm = nil
+	}
+
+	if _, err := applyToggle(ctx, changes, toggleRun{enable: enable, dryRun: dryRun, confirm: confirm}); err != nil {
+		fatalWithHint("Failed to apply change", err)
+	}
+}
+
+// toggleRun holds the options that decide whether the planned changes of a toggle are applied.
+type toggleRun struct {
+	enable  bool
+	dryRun  bool
+	confirm func(pending []toggleChange) (bool, error) // Asks for confirmation before applying, nil applies without asking.
+}
+
+// applyToggle shows the planned changes and applies the ones that change the state, the applied changes are returned.
+func applyToggle(ctx context.Context, changes []toggleChange, run toggleRun) ([]toggleChange, error) {
+	// Show the plan
+	pending := make([]toggleChange, 0, len(changes))
+	for _, change := range changes {
+		if change.enabled == run.enable {
+			logger.Info("Unchanged", change.kind, change.name, "id", change.id, "state", stateLabel(change.enabled))
+			continue
+		}
+		logger.Info("Planned change", change.kind, change.name, "id", change.id, "from", stateLabel(change.enabled), "to", stateLabel(run.enable))
+		pending = append(pending, change)
+	}
+
+	if len(pending) == 0 {
+		logger.Info("Nothing to do")
+		return nil, nil
+	}
+	if run.dryRun {
+		logger.Info("Dry run, no changes applied", "changes", len(pending))
+		return nil, nil
+	}
+
+	if run.confirm != nil {
+		confirmed, err := run.confirm(pending)
+		if err != nil {
+			return nil, fmt.Errorf("confirmation failed: %w", err)
+		}
+		if !confirmed {
+			logger.Info("Aborted, no changes applied")
+			return nil, nil
+		}
+	}
+
+	applied := make([]toggleChange, 0, len(pending))
+	for _, change := range pending {
+		if err := change.apply(ctx); err != nil {
+			return applied, err
+		}
+		logger.Info("Applied", change.kind, change.name, "id", change.id, "state", stateLabel(run.enable))
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
+
+// planToggle looks up the current state of the resources affected by a toggle.
+func planToggle(ctx context.Context, tc laneclient.TenantClient, kind string, resourceId string, enable bool) ([]toggleChange, error) {
+	workflowChange := func(workflowId string) (toggleChange, error) {
+		workflow, err := tc.GetWorkflow(ctx, workflowId)
+		if err != nil {
+			return toggleChange{}, err
+		}
+		return toggleChange{
+			kind:    "workflow",
+			id:      workflowId,
+			name:    workflow.Playbook.Title,
+			enabled: workflow.Meta.Enabled,
+			apply: func(ctx context.Context) error {
+				return tc.SetWorkflowEnabled(ctx, workflowId, enable)
+			},
+		}, nil
+	}
+
+	switch kind {
+	case "playbook":
+		for playbook, err := range tc.StreamPlaybooks(ctx) {
+			if err != nil {
+				return nil, err
+			}
+			if playbook.Id != resourceId {
+				continue
+			}
+			changes := make([]toggleChange, 0, len(playbook.PlaybookIds))
+			for _, workflowId := range playbook.PlaybookIds {
+				change, err := workflowChange(workflowId)
+				if err != nil {
+					return nil, err
+				}
+				change.name = playbook.Name + " / " + change.name
+				changes = append(changes, change)
+			}
+			return changes, nil
+		}
+		return nil, fmt.Errorf("playbook %s not found", resourceId)
+
+	case "workflow":
+		change, err := workflowChange(resourceId)
+		if err != nil {
+			return nil, err
+		}
+		return []toggleChange{change}, nil
+
+	case "task":
+		task, err := tc.GetOrchestrationTask(ctx, resourceId)
+		if err != nil {
+			return nil, err
+		}
+		return []toggleChange{{
+			kind:    "task",
+			id:      task.Id,
+			name:    task.Name,
+			enabled: !task.Disabled,
+			apply: func(ctx context.Context) error {
+				return tc.SetOrchestrationTaskDisabled(ctx, resourceId, !enable)
+			},
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown resource kind %q, expected playbook, workflow, or task", kind)
+}
+
+// stateLabel returns a human-readable label for an enabled state.
+func stateLabel(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
@@ -0,0 +1,167 @@
+package swimpeek
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+const workflowPath = "/orchestration/api/account/acc/tenant/t1/v1/playbook/w1"
+
+// fakeWorkflowServer serves a single workflow and records the bodies of the updates.
+type fakeWorkflowServer struct {
+	*httptest.Server
+	mu   sync.Mutex
+	doc  map[string]any
+	puts []map[string]any
+}
+
+func newFakeWorkflowServer(t *testing.T, enabled bool) *fakeWorkflowServer {
+	srv := &fakeWorkflowServer{doc: map[string]any{
+		"id":       "w1",
+		"version":  3,
+		"meta":     map[string]any{"enabled": enabled, "solutionId": "s1"},
+		"playbook": map[string]any{"title": "Enrich alert", "actions": map[string]any{"a1": map[string]any{"type": "action"}}},
+	}}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		if r.URL.Path != workflowPath {
+			t.Errorf("unexpected path %s, want %s", r.URL.Path, workflowPath)
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", `"v3"`)
+			json.NewEncoder(w).Encode(srv.doc) //nolint:errcheck
+		case http.MethodPut:
+			if r.Header.Get("If-Match") != `"v3"` {
+				http.Error(w, `{"message":"precondition failed"}`, http.StatusPreconditionFailed)
+				return
+			}
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode PUT body: %v", err)
+			}
+			srv.puts = append(srv.puts, body)
+			io.WriteString(w, `{}`) //nolint:errcheck
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	return srv
+}
+
+// planWorkflowToggle plans a toggle of the served workflow.
+func (srv *fakeWorkflowServer) planWorkflowToggle(t *testing.T, enable bool) []toggleChange {
+	t.Helper()
+	lc := laneclient.NewLaneClient("swimlane.invalid", "acc", "secret", log.New(io.Discard),
+		laneclient.WithBaseURL(srv.URL), laneclient.WithRetryPolicy(laneclient.RetryPolicy{}))
+	changes, err := planToggle(context.Background(), laneclient.NewTenantClient(lc, laneclient.Tenant{Id: "t1"}), "workflow", "w1", enable)
+	if err != nil {
+		t.Fatalf("planToggle failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].name != "Enrich alert" || changes[0].enabled == enable {
+		t.Fatalf("unexpected plan: %+v", changes)
+	}
+	return changes
+}
+
+func TestToggleDryRun(t *testing.T) {
+	srv := newFakeWorkflowServer(t, false)
+	defer srv.Close()
+
+	confirm := func([]toggleChange) (bool, error) {
+		t.Error("a dry run must not ask for confirmation")
+		return true, nil
+	}
+	applied, err := applyToggle(context.Background(), srv.planWorkflowToggle(t, true), toggleRun{enable: true, dryRun: true, confirm: confirm})
+	if err != nil {
+		t.Fatalf("applyToggle failed: %v", err)
+	}
+	if len(applied) != 0 || len(srv.puts) != 0 {
+		t.Errorf("dry run applied %d change(s) with %d update(s)", len(applied), len(srv.puts))
+	}
+}
+
+func TestToggleConfirm(t *testing.T) {
+	for _, confirmed := range []bool{false, true} {
+		t.Run(map[bool]string{false: "declined", true: "confirmed"}[confirmed], func(t *testing.T) {
+			srv := newFakeWorkflowServer(t, false)
+			defer srv.Close()
+
+			var asked []toggleChange
+			confirm := func(pending []toggleChange) (bool, error) {
+				asked = pending
+				return confirmed, nil
+			}
+			applied, err := applyToggle(context.Background(), srv.planWorkflowToggle(t, true), toggleRun{enable: true, confirm: confirm})
+			if err != nil {
+				t.Fatalf("applyToggle failed: %v", err)
+			}
+			if len(asked) != 1 {
+				t.Errorf("confirmation asked for %d change(s), want 1", len(asked))
+			}
+
+			want := 0
+			if confirmed {
+				want = 1
+			}
+			if len(applied) != want || len(srv.puts) != want {
+				t.Errorf("applied %d change(s) with %d update(s), want %d", len(applied), len(srv.puts), want)
+			}
+		})
+	}
+}
+
+func TestToggleUnchanged(t *testing.T) {
+	srv := newFakeWorkflowServer(t, true)
+	defer srv.Close()
+
+	changes := srv.planWorkflowToggle(t, false)
+	changes[0].enabled = false // Pretend the workflow is in the target state already
+	confirm := func([]toggleChange) (bool, error) {
+		t.Error("nothing to change, confirmation must not be asked")
+		return true, nil
+	}
+	if _, err := applyToggle(context.Background(), changes, toggleRun{enable: false, confirm: confirm}); err != nil {
+		t.Fatalf("applyToggle failed: %v", err)
+	}
+	if len(srv.puts) != 0 {
+		t.Errorf("unchanged workflow was updated %d time(s)", len(srv.puts))
+	}
+}
+
+func TestTogglePutBody(t *testing.T) {
+	srv := newFakeWorkflowServer(t, true)
+	defer srv.Close()
+
+	if _, err := applyToggle(context.Background(), srv.planWorkflowToggle(t, false), toggleRun{enable: false}); err != nil {
+		t.Fatalf("applyToggle failed: %v", err)
+	}
+	if len(srv.puts) != 1 {
+		t.Fatalf("updates = %d, want 1", len(srv.puts))
+	}
+
+	// Only the enabled state changes, the rest of the document is written back as read
+	want := map[string]any{
+		"id":       "w1",
+		"version":  float64(3),
+		"meta":     map[string]any{"enabled": false, "solutionId": "s1"},
+		"playbook": map[string]any{"title": "Enrich alert", "actions": map[string]any{"a1": map[string]any{"type": "action"}}},
+	}
+	got, _ := json.Marshal(srv.puts[0])
+	expected, _ := json.Marshal(want)
+	if string(got) != string(expected) {
+		t.Errorf("PUT body = %s, want %s", got, expected)
+	}
+}
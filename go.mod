@@ -9,6 +9,7 @@ require (
 	github.com/charmbracelet/lipgloss v1.1.0
 	github.com/charmbracelet/log v0.4.2
 	github.com/google/uuid v1.6.0
+	github.com/klauspost/compress v1.18.0
 	golang.org/x/sync v0.17.0
 )
 
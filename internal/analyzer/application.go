@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/just-oblivious/swimpeek/internal/graph"
 	"github.com/just-oblivious/swimpeek/pkg/laneclient"
@@ -11,9 +12,15 @@ type TriggerAction struct {
 	Trigger  *graph.Node
 	Playbook *graph.Node
 	Workflow *graph.Node
+	Task     *graph.Node // Legacy task started by a legacy trigger, Playbook and Workflow are nil in that case.
 	Enabled  bool
 }
 
+// IsLegacyTrigger returns true if this trigger starts a legacy task instead of a playbook-workflow.
+func (t *TriggerAction) IsLegacyTrigger() bool {
+	return t.Task != nil
+}
+
 type ApplicationTriggersResult struct {
 	ButtonTriggers      []TriggerAction
 	RecordEventTriggers []TriggerAction
@@ -24,6 +31,7 @@ type AccessAction struct {
 	Playbook      *graph.Node
 	Component     *graph.Node
 	Workflow      *graph.Node
+	Task          *graph.Node // Legacy task accessing the application, Action is the task itself in that case.
 	Enabled       bool
 	InspectionErr error // Error encountered during field-level inspection of an action, if any.
 
@@ -34,6 +42,11 @@ func (a *AccessAction) IsComponentAction() bool {
 	return a.Component != nil
 }
 
+// IsLegacyAction returns true if this access action is a legacy task.
+func (a *AccessAction) IsLegacyAction() bool {
+	return a.Task != nil
+}
+
 // ApplicationTriggers analyzes which playbook-workflows can be triggered by the given application.
 func (a *Analyzer) ApplicationTriggers(appNode *graph.Node) *ApplicationTriggersResult {
 
@@ -57,19 +70,46 @@ func (a *Analyzer) ApplicationTriggers(appNode *graph.Node) *ApplicationTriggers
 		return triggerActions
 	}
 
+	// Legacy triggers may start several tasks, each task is listed as a separate trigger action
+	createLegacyTriggersFn := func(trigs map[*graph.Node]bool) []TriggerAction {
+		triggerActions := make([]TriggerAction, 0, len(trigs))
+		for _, trig := range SortSetByLabel(trigs) {
+			trigEnabled := false
+			if trigResource := a.GetLegacyTriggerResource(trig); trigResource != nil {
+				trigEnabled = !trigResource.Disabled
+			}
+			taskNodes := a.FindUnique(trig, NewWalkOpts(Descend, WithFollowEdgeTypes(graph.TriggersTaskEdge), WithMaxDepth(1)), graph.LegacyTaskNode)
+			for _, taskNode := range SortSetByLabel(taskNodes) {
+				taskResource := a.GetLegacyTaskResource(taskNode)
+				triggerActions = append(triggerActions, TriggerAction{
+					Trigger: trig,
+					Task:    taskNode,
+					Enabled: trigEnabled && taskResource != nil && !taskResource.Disabled,
+				})
+			}
+		}
+		return triggerActions
+	}
+
 	btnNodes := a.FindUnique(appNode, NewWalkOpts(Descend, WithMaxDepth(1)), graph.PlaybookButtonNode)
 	pbButtons := createTriggersFn(btnNodes)
 
 	evtNodes := a.FindUnique(appNode, NewWalkOpts(Descend, WithMaxDepth(1)), graph.RecordEventNode)
 	recordEvents := createTriggersFn(evtNodes)
 
+	legacyBtnNodes := a.FindUnique(appNode, NewWalkOpts(Descend, WithFollowEdgeTypes(graph.HasActionEdge), WithMaxDepth(1)), graph.LegacyTriggerNode)
+	pbButtons = append(pbButtons, createLegacyTriggersFn(legacyBtnNodes)...)
+
+	legacyEvtNodes := a.FindUnique(appNode, NewWalkOpts(Descend, WithFollowEdgeTypes(graph.HasEventEdge), WithMaxDepth(1)), graph.LegacyTriggerNode)
+	recordEvents = append(recordEvents, createLegacyTriggersFn(legacyEvtNodes)...)
+
 	return &ApplicationTriggersResult{
 		ButtonTriggers:      pbButtons,
 		RecordEventTriggers: recordEvents,
 	}
 }
 
-// ApplicationAccessedBy analyzes which components and playbook-workflow actions access records in this application.
+// ApplicationAccessedBy analyzes which components, playbook-workflow actions, and legacy tasks access records in this application.
 func (a *Analyzer) ApplicationAccessedBy(appNode *graph.Node) []AccessAction {
 	recordAccessActions := a.FindAll(appNode, NewWalkOpts(Descend, WithFollowEdgeTypes(graph.AccessedByEdge)), graph.RecordCreateActionNode, graph.RecordUpsertActionNode, graph.RecordUpdateActionNode, graph.RecordDeleteActionNode, graph.RecordSearchActionNode, graph.RecordExportActionNode)
 
@@ -110,10 +150,24 @@ func (a *Analyzer) ApplicationAccessedBy(appNode *graph.Node) []AccessAction {
 
 	}
 
+	// Legacy tasks access applications directly
+	taskNodes := a.FindUnique(appNode, NewWalkOpts(Descend, WithFollowEdgeTypes(graph.AccessedByEdge), WithMaxDepth(1)), graph.LegacyTaskNode)
+	for _, taskNode := range SortSetByLabel(taskNodes) {
+		taskEnabled := false
+		if taskResource := a.GetLegacyTaskResource(taskNode); taskResource != nil {
+			taskEnabled = !taskResource.Disabled
+		}
+		accessActions = append(accessActions, AccessAction{
+			Action:  taskNode,
+			Task:    taskNode,
+			Enabled: taskEnabled,
+		})
+	}
+
 	return accessActions
 }
 
-// ApplicationFieldModifiedBy analyzes which components and playbook-workflow actions modify the given application field.
+// ApplicationFieldModifiedBy analyzes which components, playbook-workflow actions, and legacy tasks modify the given application field.
 func (a *Analyzer) ApplicationFieldModifiedBy(appNode *graph.Node, field *laneclient.ApplicationField) []AccessAction {
 	accessActions := a.ApplicationAccessedBy(appNode)
 
@@ -155,6 +209,18 @@ func (a *Analyzer) ApplicationFieldModifiedBy(appNode *graph.Node, field *lanecl
 			continue
 		}
 
+		// Legacy tasks map their outputs to field ids
+		if action.IsLegacyAction() {
+			taskResource := a.GetLegacyTaskResource(action.Task)
+			if taskResource == nil {
+				action.InspectionErr = fmt.Errorf("legacy task resource not found")
+				filteredActions = append(filteredActions, action)
+			} else if taskResource.WrittenFieldIds(appNode.Meta.Id)[field.Id] {
+				filteredActions = append(filteredActions, action)
+			}
+			continue
+		}
+
 		actionResource := a.GetActionResource(action.Workflow, action.Action)
 		if actionResource == nil {
 			action.InspectionErr = fmt.Errorf("action resource not found")
@@ -183,3 +249,43 @@ func (a *Analyzer) ApplicationFieldModifiedBy(appNode *graph.Node, field *lanecl
 
 	return filteredActions
 }
+
+type AppRelation struct {
+	App    *graph.Node
+	Fields []string // Names of the reference fields linking both applications.
+}
+
+type ApplicationRelationsResult struct {
+	References   []AppRelation // Applications referenced by fields of this application.
+	ReferencedBy []AppRelation // Applications with fields referencing this application.
+}
+
+// ApplicationRelations analyzes which applications are related to the given application through reference fields, in both directions.
+func (a *Analyzer) ApplicationRelations(appNode *graph.Node) *ApplicationRelationsResult {
+	collectFn := func(edges []*graph.Edge, outgoing bool) []AppRelation {
+		fieldsByApp := make(map[*graph.Node][]string)
+		for _, edge := range edges {
+			if edge.Type != graph.ReferencesAppEdge {
+				continue
+			}
+			relNode := edge.Src
+			if outgoing {
+				relNode = edge.Dst
+			}
+			fieldsByApp[relNode] = append(fieldsByApp[relNode], edge.Meta.Label)
+		}
+
+		relations := make([]AppRelation, 0, len(fieldsByApp))
+		for _, relNode := range SortSetByLabel(fieldsByApp) {
+			fields := fieldsByApp[relNode]
+			slices.Sort(fields)
+			relations = append(relations, AppRelation{App: relNode, Fields: fields})
+		}
+		return relations
+	}
+
+	return &ApplicationRelationsResult{
+		References:   collectFn(appNode.Out, true),
+		ReferencedBy: collectFn(appNode.In, false),
+	}
+}
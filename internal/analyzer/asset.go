@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"github.com/just-oblivious/swimpeek/internal/graph"
+)
+
+type AssetUsedByResult struct {
+	Actions                   map[*graph.Node]bool
+	Components                map[*graph.Node]bool
+	PlaybookWorkflows         map[*graph.Node]map[*graph.Node]bool
+	IndirectPlaybookWorkflows map[*graph.Node]map[*graph.Node]bool // Playbook-workflows that use the asset through a (nested) component.
+	LegacyTasks               map[*graph.Node]bool
+}
+
+// AssetUsedBy analyzes which actions, components, and playbook-workflows use the given asset.
+// Playbooks that call a component using the asset are reported as indirect users, as they are affected when the asset changes.
+func (a *Analyzer) AssetUsedBy(assetNode *graph.Node) *AssetUsedByResult {
+	// Find actions that use this asset
+	usedByActions := make(map[*graph.Node]bool)
+	usedByLegacyTasks := make(map[*graph.Node]bool)
+	for _, edge := range assetNode.Out {
+		if edge.Type != graph.UsedByEdge {
+			continue
+		}
+		if edge.Dst.Meta.Type == graph.LegacyTaskNode {
+			usedByLegacyTasks[edge.Dst] = true
+			continue
+		}
+		usedByActions[edge.Dst] = true
+	}
+
+	usedByComponents, usedByPbWorkflows := a.groupActionContainers(usedByActions)
+
+	// Walk up the component call chain to find playbooks that use the asset indirectly
+	indirectPbWorkflows := make(map[*graph.Node]map[*graph.Node]bool)
+	visited := make(map[*graph.Node]bool, len(usedByComponents))
+	queue := SortSetByLabel(usedByComponents)
+	for len(queue) > 0 {
+		comp := queue[0]
+		queue = queue[1:]
+		if visited[comp] {
+			continue
+		}
+		visited[comp] = true
+
+		calledBy := a.ComponentCalledBy(comp)
+		for pbNode, wfNodes := range calledBy.PlaybookWorkflows {
+			if _, exists := indirectPbWorkflows[pbNode]; !exists {
+				indirectPbWorkflows[pbNode] = make(map[*graph.Node]bool)
+			}
+			for wfNode := range wfNodes {
+				indirectPbWorkflows[pbNode][wfNode] = true
+			}
+		}
+		queue = append(queue, SortSetByLabel(calledBy.Components)...)
+	}
+
+	return &AssetUsedByResult{
+		Actions:                   usedByActions,
+		Components:                usedByComponents,
+		PlaybookWorkflows:         usedByPbWorkflows,
+		IndirectPlaybookWorkflows: indirectPbWorkflows,
+		LegacyTasks:               usedByLegacyTasks,
+	}
+}
+
+// AssetPlaybooks returns all playbooks that use the given asset, either directly or through a component.
+func (a *Analyzer) AssetPlaybooks(assetNode *graph.Node) map[*graph.Node]bool {
+	usedBy := a.AssetUsedBy(assetNode)
+	playbooks := make(map[*graph.Node]bool, len(usedBy.PlaybookWorkflows)+len(usedBy.IndirectPlaybookWorkflows))
+	for pbNode := range usedBy.PlaybookWorkflows {
+		playbooks[pbNode] = true
+	}
+	for pbNode := range usedBy.IndirectPlaybookWorkflows {
+		playbooks[pbNode] = true
+	}
+	return playbooks
+}
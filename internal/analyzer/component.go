@@ -20,40 +20,46 @@ func (a *Analyzer) ComponentCalledBy(compNode *graph.Node) *ComponentCalledByRes
 		}
 	}
 
-	// Find the workflows containing the call actions
-	calledByWorkflows := make(map[*graph.Node]bool)
-	for actionNode := range calledByActions {
+	// Find the components and playbook-workflows containing the call actions
+	calledByComponents, calledByPbWorkflows := a.groupActionContainers(calledByActions)
+
+	return &ComponentCalledByResult{
+		Actions:           calledByActions,
+		Components:        calledByComponents,
+		PlaybookWorkflows: calledByPbWorkflows,
+	}
+}
+
+// groupActionContainers finds the components and playbook-workflows that contain the given actions.
+func (a *Analyzer) groupActionContainers(actions map[*graph.Node]bool) (map[*graph.Node]bool, map[*graph.Node]map[*graph.Node]bool) {
+	// Find the workflows containing the actions
+	workflows := make(map[*graph.Node]bool)
+	for actionNode := range actions {
 		wfNode := a.GetWorkflowForAction(actionNode)
 		if wfNode != nil {
-			calledByWorkflows[wfNode] = true
+			workflows[wfNode] = true
 		}
 	}
 
 	// Find components and playbooks containing the workflows
-	calledByComponents := make(map[*graph.Node]bool)
-	calledByPbWorkflows := make(map[*graph.Node]map[*graph.Node]bool)
-	for wfNode := range calledByWorkflows {
+	components := make(map[*graph.Node]bool)
+	pbWorkflows := make(map[*graph.Node]map[*graph.Node]bool)
+	for wfNode := range workflows {
 		comp := a.GetComponentForWorkflow(wfNode)
 		if comp != nil {
-			calledByComponents[comp] = true
-			delete(calledByWorkflows, wfNode)
+			components[comp] = true
 		}
 
 		pbNode := a.GetPlaybookForWorkflow(wfNode)
 		if pbNode != nil {
-			if _, exists := calledByPbWorkflows[pbNode]; !exists {
-				calledByPbWorkflows[pbNode] = make(map[*graph.Node]bool)
+			if _, exists := pbWorkflows[pbNode]; !exists {
+				pbWorkflows[pbNode] = make(map[*graph.Node]bool)
 			}
-			calledByPbWorkflows[pbNode][wfNode] = true
-			delete(calledByWorkflows, wfNode)
+			pbWorkflows[pbNode][wfNode] = true
 		}
 	}
 
-	return &ComponentCalledByResult{
-		Actions:           calledByActions,
-		Components:        calledByComponents,
-		PlaybookWorkflows: calledByPbWorkflows,
-	}
+	return components, pbWorkflows
 }
 
 // ComponentCalls returns nodes that the given component node calls.
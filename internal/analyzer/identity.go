@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+type AccessGrant struct {
+	Role   *graph.Node
+	Access laneclient.AccessLevel
+	Groups []*graph.Node // Groups that have the role.
+	Users  []*graph.Node // Users that have the role, either directly or through a group.
+}
+
+type PlaybookButtonGrantsResult struct {
+	Button           *graph.Node
+	Playbook         *graph.Node
+	ApplicationRoles []AccessGrant
+	PlaybookRoles    []AccessGrant // Roles with execute permission on the playbook, empty if the playbook is not restricted.
+	Runners          []*graph.Node // Users that can run the button (including administrators).
+}
+
+// ResourceGrants analyzes which roles grant access to the given application or playbook, and which users and groups hold these roles.
+func (a *Analyzer) ResourceGrants(resNode *graph.Node) []AccessGrant {
+	roleNodes := a.FindUnique(resNode, NewWalkOpts(Ascend, WithFollowEdgeTypes(graph.GrantsEdge), WithMaxDepth(1)), graph.RoleNode)
+
+	grants := make([]AccessGrant, 0, len(roleNodes))
+	for _, roleNode := range SortSetByLabel(roleNodes) {
+		groups := a.FindUnique(roleNode, NewWalkOpts(Ascend, WithFollowEdgeTypes(graph.HasRoleEdge), WithMaxDepth(1)), graph.GroupNode)
+		users := a.FindUnique(roleNode, NewWalkOpts(Ascend, WithFollowEdgeTypes(graph.HasRoleEdge, graph.MemberOfEdge), WithMaxDepth(2)), graph.UserNode)
+
+		grants = append(grants, AccessGrant{
+			Role:   roleNode,
+			Access: a.roleAccess(roleNode, resNode),
+			Groups: SortSetByLabel(groups),
+			Users:  SortSetByLabel(users),
+		})
+	}
+
+	return grants
+}
+
+// PlaybookButtonGrants analyzes who can run the given playbook button.
+// Running a button requires update access on the application records; when a role grants execute permission on the playbook,
+// only holders of such a role may run it.
+func (a *Analyzer) PlaybookButtonGrants(appNode *graph.Node, btnNode *graph.Node) *PlaybookButtonGrantsResult {
+	result := &PlaybookButtonGrantsResult{
+		Button:           btnNode,
+		ApplicationRoles: a.ResourceGrants(appNode),
+	}
+
+	if wfNode := a.GetWorkflowForTrigger(btnNode); wfNode != nil {
+		result.Playbook = a.GetPlaybookForWorkflow(wfNode)
+	}
+	if result.Playbook != nil {
+		for _, grant := range a.ResourceGrants(result.Playbook) {
+			if grant.Access.Has(laneclient.AccessExecute) {
+				result.PlaybookRoles = append(result.PlaybookRoles, grant)
+			}
+		}
+	}
+
+	appUsers := make(map[*graph.Node]bool)
+	for _, grant := range result.ApplicationRoles {
+		if grant.Access.Has(laneclient.AccessUpdate) {
+			for _, user := range grant.Users {
+				appUsers[user] = true
+			}
+		}
+	}
+
+	runners := appUsers
+	if len(result.PlaybookRoles) > 0 {
+		runners = make(map[*graph.Node]bool)
+		for _, grant := range result.PlaybookRoles {
+			for _, user := range grant.Users {
+				if appUsers[user] {
+					runners[user] = true
+				}
+			}
+		}
+	}
+
+	// Administrators bypass role permissions.
+	for userId, user := range a.Lanestate.UsersById {
+		if user.IsAdmin && !user.Disabled {
+			if userNode, exists := a.Graph.Resources.UsersById[userId]; exists {
+				runners[userNode] = true
+			}
+		}
+	}
+
+	result.Runners = SortSetByLabel(runners)
+	return result
+}
+
+// roleAccess returns the combined access level the role grants on the given resource.
+func (a *Analyzer) roleAccess(roleNode *graph.Node, resNode *graph.Node) laneclient.AccessLevel {
+	role, exists := a.Lanestate.RolesById[roleNode.Meta.Id]
+	if !exists {
+		return 0
+	}
+
+	var access laneclient.AccessLevel
+	for _, perm := range role.Permissions {
+		if perm.ResourceId == resNode.Meta.Id {
+			access |= perm.Access
+		}
+	}
+	return access
+}
@@ -27,7 +27,8 @@ func (a *Analyzer) GetWorkflowsForPlaybook(pbNode *graph.Node) []*graph.Node {
 
 // GetWorkflowForAction returns the workflow in which the given action node is contained
 func (a *Analyzer) GetWorkflowForAction(actionNode *graph.Node) *graph.Node {
-	return a.FindFirst(actionNode, NewWalkOpts(Ascend), graph.WorkflowNode)
+	// Applications may reference each other in cycles, don't walk from one application to another
+	return a.FindFirst(actionNode, NewWalkOpts(Ascend, WithSkipEdgeTypes(graph.ReferencesAppEdge)), graph.WorkflowNode)
 }
 
 // GetTriggersForWorkflow returns the triggers associated with the given workflow node.
@@ -56,6 +57,7 @@ func (a *Analyzer) GetReferences(node *graph.Node) map[*graph.Node]bool {
 		graph.AccessedByEdge,
 		graph.HasActionEdge,
 		graph.CalledByEdge,
+		graph.UsedByEdge,
 		graph.EmittedByEdge,
 		graph.HasEventEdge,
 	}
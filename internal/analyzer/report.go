@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+type ReportUsage struct {
+	Report     *graph.Node
+	Dashboards []*graph.Node // Dashboards displaying the report.
+	Workspaces []*graph.Node // Workspaces containing the dashboards.
+}
+
+// ApplicationReports analyzes which reports are based on the given application and on which dashboards they are displayed.
+func (a *Analyzer) ApplicationReports(appNode *graph.Node) []ReportUsage {
+	reportNodes := a.FindUnique(appNode, NewWalkOpts(Descend, WithFollowEdgeTypes(graph.ReportedByEdge), WithMaxDepth(1)), graph.ReportNode)
+
+	usages := make([]ReportUsage, 0, len(reportNodes))
+	for _, reportNode := range SortSetByLabel(reportNodes) {
+		dashboards := a.FindUnique(reportNode, NewWalkOpts(Ascend, WithFollowEdgeTypes(graph.DisplaysEdge), WithMaxDepth(1)), graph.DashboardNode)
+		workspaces := make(map[*graph.Node]bool)
+		for dbNode := range dashboards {
+			for wsNode := range a.FindUnique(dbNode, NewWalkOpts(Ascend, WithFollowEdgeTypes(graph.ContainsEdge), WithMaxDepth(1)), graph.WorkspaceNode) {
+				workspaces[wsNode] = true
+			}
+		}
+
+		usages = append(usages, ReportUsage{
+			Report:     reportNode,
+			Dashboards: SortSetByLabel(dashboards),
+			Workspaces: SortSetByLabel(workspaces),
+		})
+	}
+
+	return usages
+}
+
+// ApplicationFieldReports analyzes which reports show or filter on the given application field.
+func (a *Analyzer) ApplicationFieldReports(appNode *graph.Node, field *laneclient.ApplicationField) []ReportUsage {
+	usages := a.ApplicationReports(appNode)
+
+	filtered := make([]ReportUsage, 0, len(usages))
+	for _, usage := range usages {
+		report := a.GetReportResource(usage.Report)
+		if report == nil {
+			continue
+		}
+		if report.FieldIds()[field.Id] {
+			filtered = append(filtered, usage)
+		}
+	}
+
+	return filtered
+}
+
+// GetWorkspacesForApplication returns the workspaces that contain the given application.
+func (a *Analyzer) GetWorkspacesForApplication(appNode *graph.Node) map[*graph.Node]bool {
+	return a.FindUnique(appNode, NewWalkOpts(Ascend, WithFollowEdgeTypes(graph.ContainsEdge), WithMaxDepth(1)), graph.WorkspaceNode)
+}
@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
 	"github.com/just-oblivious/swimpeek/pkg/laneclient"
 )
 
@@ -21,6 +22,24 @@ func (a *Analyzer) GetApplicationResource(appNode *graph.Node) *laneclient.Appli
 	return nil
 }
 
+// GetReportResource returns the report resource associated with the given report node, if it exists.
+func (a *Analyzer) GetReportResource(reportNode *graph.Node) *laneclient.Report {
+	if report, exists := a.Lanestate.ReportsById[reportNode.Meta.Id]; exists {
+		return &report
+	}
+	return nil
+}
+
+// GetOrchestrationTaskResource returns the orchestration task associated with the given playbook button or record event node, if it exists.
+func (a *Analyzer) GetOrchestrationTaskResource(triggerNode *graph.Node) *laneclient.OrchestrationTask {
+	for _, task := range a.Lanestate.OrchestrationTasks {
+		if task.Id == triggerNode.Meta.Id {
+			return &task
+		}
+	}
+	return nil
+}
+
 // GetActionResource returns the playbook action associated with the given action node within the specified workflow, if it exists.
 func (a *Analyzer) GetActionResource(wfNode *graph.Node, actNode *graph.Node) *laneclient.PlaybookAction {
 	wfResource := a.GetWorkflowResource(wfNode)
@@ -44,3 +63,24 @@ func (a *Analyzer) GetActionResource(wfNode *graph.Node, actNode *graph.Node) *l
 
 	return findActFn(wfResource.Playbook.Actions)
 }
+
+// GetSolutionHistory returns the revisions of the given playbook or component stored in the dump, newest first.
+func (a *Analyzer) GetSolutionHistory(solNode *graph.Node) []lanedump.SolutionRevision {
+	return a.Lanestate.HistoryBySolutionId[solNode.Meta.Id]
+}
+
+// GetLegacyTaskResource returns the legacy task associated with the given legacy task node, if it exists.
+func (a *Analyzer) GetLegacyTaskResource(taskNode *graph.Node) *laneclient.LegacyTask {
+	if task, exists := a.Lanestate.LegacyTasksById[taskNode.Meta.Id]; exists {
+		return &task
+	}
+	return nil
+}
+
+// GetLegacyTriggerResource returns the legacy trigger associated with the given legacy trigger node, if it exists.
+func (a *Analyzer) GetLegacyTriggerResource(triggerNode *graph.Node) *laneclient.LegacyTrigger {
+	if trigger, exists := a.Lanestate.LegacyTriggersById[triggerNode.Meta.Id]; exists {
+		return &trigger
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"time"
+
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+)
+
+// WorkflowRunMetrics returns the run metrics of the given workflow node, nil if the dump has no run history.
+// Workflows without runs in the history period return empty metrics.
+func (a *Analyzer) WorkflowRunMetrics(wfNode *graph.Node) *lanedump.WorkflowRunMetrics {
+	if !a.Lanestate.HasRunHistory() {
+		return nil
+	}
+	metrics := a.Lanestate.RunMetricsByWorkflowId[wfNode.Meta.Id]
+	return &metrics
+}
+
+// ActionRunMetrics returns the run metrics of the given action node within the specified workflow, nil if the dump has no run history.
+func (a *Analyzer) ActionRunMetrics(wfNode *graph.Node, actNode *graph.Node) *lanedump.RunMetrics {
+	wfMetrics := a.WorkflowRunMetrics(wfNode)
+	if wfMetrics == nil {
+		return nil
+	}
+	metrics := wfMetrics.ActionsById[actNode.Meta.Id]
+	return &metrics
+}
+
+// PlaybookRunMetrics returns the combined run metrics of all workflows in the given playbook, nil if the dump has no run history.
+func (a *Analyzer) PlaybookRunMetrics(pbNode *graph.Node) *lanedump.RunMetrics {
+	if !a.Lanestate.HasRunHistory() {
+		return nil
+	}
+
+	combined := lanedump.RunMetrics{}
+	var totalDuration time.Duration
+	for _, wfNode := range a.GetWorkflowsForPlaybook(pbNode) {
+		metrics := a.Lanestate.RunMetricsByWorkflowId[wfNode.Meta.Id]
+		combined.Runs += metrics.Runs
+		combined.Failures += metrics.Failures
+		combined.Completed += metrics.Completed
+		// Averages are taken over completed runs, running or aborted runs have no duration
+		totalDuration += metrics.AverageDuration * time.Duration(metrics.Completed)
+	}
+	if combined.Completed > 0 {
+		combined.AverageDuration = totalDuration / time.Duration(combined.Completed)
+	}
+	return &combined
+}
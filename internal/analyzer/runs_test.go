@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+func TestPlaybookRunMetrics(t *testing.T) {
+	state := &lanedump.LaneState{
+		PlaybooksById: map[string]laneclient.OrchestrationSolution{"p1": {Id: "p1", Name: "Playbook", PlaybookIds: []string{"w1", "w2"}}},
+		WorkflowsById: map[string]laneclient.Workflow{"w1": {Id: "w1"}, "w2": {Id: "w2"}},
+		RunMetricsByWorkflowId: map[string]lanedump.WorkflowRunMetrics{
+			// Most runs of w1 are still running, only the completed runs count towards the average
+			"w1": {RunMetrics: lanedump.RunMetrics{Runs: 10, Completed: 1, AverageDuration: 10 * time.Second}},
+			"w2": {RunMetrics: lanedump.RunMetrics{Runs: 3, Completed: 3, AverageDuration: 2 * time.Second}},
+		},
+	}
+	g, _, err := graph.FromState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := NewAnalyzer(state, g).PlaybookRunMetrics(g.Resources.PlaybooksById["p1"])
+	if metrics.Runs != 13 || metrics.Completed != 4 {
+		t.Errorf("runs = %d, completed = %d, want 13 and 4", metrics.Runs, metrics.Completed)
+	}
+	if want := 4 * time.Second; metrics.AverageDuration != want {
+		t.Errorf("average duration = %s, want %s", metrics.AverageDuration, want)
+	}
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+type SensorUsageResult struct {
+	Triggers          []TriggerAction                      // Playbook-workflows started by the sensor.
+	Emitters          map[*graph.Node]bool                 // Emit event actions raising the flow event.
+	EmitterComponents map[*graph.Node]bool                 // Components containing emit event actions.
+	EmitterWorkflows  map[*graph.Node]map[*graph.Node]bool // Playbook-workflows containing emit event actions.
+}
+
+// GetSensorResource returns the sensor associated with the given webhook or flow event node, if it exists.
+// Sensor nodes are identified by the sensor name.
+func (a *Analyzer) GetSensorResource(sensorNode *graph.Node) *laneclient.Sensor {
+	for _, sensor := range a.Lanestate.SensorsById {
+		if sensor.Meta.Name == sensorNode.Meta.Id {
+			return &sensor
+		}
+	}
+	return nil
+}
+
+// SensorUsage analyzes which playbook-workflows consume the given webhook or flow event, and which actions emit it.
+func (a *Analyzer) SensorUsage(sensorNode *graph.Node) *SensorUsageResult {
+	wfNodes := a.FindUnique(sensorNode, NewWalkOpts(Descend, WithFollowEdgeTypes(graph.TriggersWorkflowEdge), WithMaxDepth(1)), graph.WorkflowNode)
+
+	triggers := make([]TriggerAction, 0, len(wfNodes))
+	for _, wfNode := range SortSetByLabel(wfNodes) {
+		pbNode := a.GetPlaybookForWorkflow(wfNode)
+		if pbNode == nil {
+			continue
+		}
+		wfEnabled := false
+		if wfResource := a.GetWorkflowResource(wfNode); wfResource != nil {
+			wfEnabled = wfResource.Meta.Enabled
+		}
+		triggers = append(triggers, TriggerAction{
+			Trigger:  sensorNode,
+			Playbook: pbNode,
+			Workflow: wfNode,
+			Enabled:  wfEnabled,
+		})
+	}
+
+	emitters := make(map[*graph.Node]bool)
+	for _, edge := range sensorNode.Out {
+		if edge.Type == graph.EmittedByEdge {
+			emitters[edge.Dst] = true
+		}
+	}
+	emitterComponents, emitterWorkflows := a.groupActionContainers(emitters)
+
+	return &SensorUsageResult{
+		Triggers:          triggers,
+		Emitters:          emitters,
+		EmitterComponents: emitterComponents,
+		EmitterWorkflows:  emitterWorkflows,
+	}
+}
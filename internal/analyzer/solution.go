@@ -0,0 +1,273 @@
+package analyzer
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+type SolutionDependenciesResult struct {
+	Playbooks    map[*graph.Node]bool
+	Components   map[*graph.Node]bool // Components called by the solution, including nested calls.
+	Workflows    map[*graph.Node]bool
+	Applications map[*graph.Node]bool // Applications accessed by record actions.
+	Connectors   map[*graph.Node]bool
+	Assets       map[*graph.Node]bool
+	Sensors      map[*graph.Node]bool // Webhooks and flow events triggering or emitted by the solution.
+}
+
+// SolutionDependencies analyzes which resources a playbook or component depends on, this is the content of its solution package.
+func (a *Analyzer) SolutionDependencies(rootNode *graph.Node) *SolutionDependenciesResult {
+	deps := &SolutionDependenciesResult{
+		Playbooks:    make(map[*graph.Node]bool),
+		Components:   make(map[*graph.Node]bool),
+		Workflows:    make(map[*graph.Node]bool),
+		Applications: make(map[*graph.Node]bool),
+		Connectors:   make(map[*graph.Node]bool),
+		Assets:       make(map[*graph.Node]bool),
+		Sensors:      make(map[*graph.Node]bool),
+	}
+
+	queue := []*graph.Node{rootNode}
+	for len(queue) > 0 {
+		solNode := queue[0]
+		queue = queue[1:]
+
+		var wfNodes []*graph.Node
+		switch solNode.Meta.Type {
+		case graph.PlaybookNode:
+			if deps.Playbooks[solNode] {
+				continue
+			}
+			deps.Playbooks[solNode] = true
+			wfNodes = a.GetWorkflowsForPlaybook(solNode)
+		case graph.ComponentNode:
+			if deps.Components[solNode] {
+				continue
+			}
+			deps.Components[solNode] = true
+			if wfNode := a.GetWorkflowForComponent(solNode); wfNode != nil {
+				wfNodes = append(wfNodes, wfNode)
+			}
+		default:
+			continue
+		}
+
+		for _, wfNode := range wfNodes {
+			deps.Workflows[wfNode] = true
+
+			for trigNode := range a.GetTriggersForWorkflow(wfNode) {
+				if trigNode.Meta.Type == graph.WebhookNode || trigNode.Meta.Type == graph.FlowEventNode {
+					deps.Sensors[trigNode] = true
+				}
+			}
+
+			for _, actNode := range a.FindAll(wfNode, NewWalkOpts(Descend)) {
+				for refNode := range a.GetReferences(actNode) {
+					switch refNode.Meta.Type {
+					case graph.ComponentNode:
+						queue = append(queue, refNode)
+					case graph.ApplicationNode:
+						deps.Applications[refNode] = true
+					case graph.ConnectorNode:
+						deps.Connectors[refNode] = true
+					case graph.AssetNode:
+						deps.Assets[refNode] = true
+					case graph.FlowEventNode, graph.WebhookNode:
+						deps.Sensors[refNode] = true
+					}
+				}
+			}
+		}
+	}
+
+	return deps
+}
+
+// ExtractSolution creates a partial lane state holding only the resources in the solution dependencies.
+// The result is stored next to an exported solution package, so it can be checked against a target tenant before import.
+func (a *Analyzer) ExtractSolution(deps *SolutionDependenciesResult) *lanedump.LaneState {
+	state := a.Lanestate
+	subset := &lanedump.LaneState{
+		TimeStamp:        state.TimeStamp,
+		Tenant:           state.Tenant,
+		PlaybooksById:    make(map[string]laneclient.OrchestrationSolution, len(deps.Playbooks)),
+		ComponentsById:   make(map[string]laneclient.OrchestrationSolution, len(deps.Components)),
+		WorkflowsById:    make(map[string]laneclient.Workflow, len(deps.Workflows)),
+		ApplicationsById: make(map[string]laneclient.Application, len(deps.Applications)),
+		ConnectorsById:   make(map[string]laneclient.Connector, len(deps.Connectors)),
+		SensorsById:      make(map[string]laneclient.Sensor, len(deps.Sensors)),
+		AssetsById:       make(map[string]laneclient.Asset, len(deps.Assets)),
+	}
+
+	for node := range deps.Playbooks {
+		subset.PlaybooksById[node.Meta.Id] = state.PlaybooksById[node.Meta.Id]
+	}
+	for node := range deps.Components {
+		subset.ComponentsById[node.Meta.Id] = state.ComponentsById[node.Meta.Id]
+	}
+	for node := range deps.Workflows {
+		subset.WorkflowsById[node.Meta.Id] = state.WorkflowsById[node.Meta.Id]
+	}
+	for node := range deps.Applications {
+		subset.ApplicationsById[node.Meta.Id] = state.ApplicationsById[node.Meta.Id]
+	}
+	for node := range deps.Assets {
+		subset.AssetsById[node.Meta.Id] = state.AssetsById[node.Meta.Id]
+	}
+
+	// Connectors are referenced by manifest name and sensors by name, find the resources they belong to
+	for connId, conn := range state.ConnectorsById {
+		if deps.Connectors[a.Graph.Resources.ConnectorsById[conn.Meta.Manifest.Name]] {
+			subset.ConnectorsById[connId] = conn
+		}
+	}
+	for sensorId, sensor := range state.SensorsById {
+		if deps.Sensors[a.Graph.Resources.TriggersById[sensor.Meta.Name]] {
+			subset.SensorsById[sensorId] = sensor
+		}
+	}
+
+	// Orchestration tasks are tenant specific, but they're kept to show how the solution is triggered
+	for _, task := range state.OrchestrationTasks {
+		if _, exists := subset.WorkflowsById[task.PlaybookId]; exists {
+			subset.OrchestrationTasks = append(subset.OrchestrationTasks, task)
+		}
+	}
+
+	return subset
+}
+
+type BundleSeverity int
+
+const (
+	BundleInfo BundleSeverity = iota
+	BundleWarning
+	BundleError
+)
+
+func (s BundleSeverity) String() string {
+	switch s {
+	case BundleWarning:
+		return "warning"
+	case BundleError:
+		return "error"
+	}
+	return "info"
+}
+
+type BundleIssue struct {
+	Severity BundleSeverity
+	Kind     string // Type of the resource the issue is about.
+	Name     string
+	Id       string // Id of the resource in the bundle.
+	Message  string
+}
+
+// Resource returns the type and name of the resource the issue is about.
+func (i BundleIssue) Resource() string {
+	return i.Kind + " " + i.Name
+}
+
+// CheckSolutionBundle checks an extracted solution against the state of the tenant it will be imported into.
+// Solutions and applications are matched by Uid, connectors by manifest name, and assets by name.
+func CheckSolutionBundle(bundle *lanedump.LaneState, target *lanedump.LaneState) []BundleIssue {
+	issues := make([]BundleIssue, 0)
+	addFn := func(severity BundleSeverity, kind string, name string, id string, format string, args ...any) {
+		issues = append(issues, BundleIssue{Severity: severity, Kind: kind, Name: name, Id: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Playbooks and components are created or updated by the import
+	checkSolutionsFn := func(kind string, solutions map[string]laneclient.OrchestrationSolution, targetSolutions map[string]laneclient.OrchestrationSolution) {
+		byUid := make(map[string]laneclient.OrchestrationSolution, len(targetSolutions))
+		for _, sol := range targetSolutions {
+			byUid[sol.Uid] = sol
+		}
+		for _, sol := range solutions {
+			existing, exists := byUid[sol.Uid]
+			switch {
+			case !exists:
+				addFn(BundleInfo, kind, sol.Name, sol.Id, "will be created")
+			case existing.Version > sol.Version:
+				addFn(BundleWarning, kind, sol.Name, sol.Id, "target has a newer version (%d) than the bundle (%d), changes in the target will be overwritten", existing.Version, sol.Version)
+			case existing.Version == sol.Version:
+				addFn(BundleInfo, kind, sol.Name, sol.Id, "version %d already present in target", sol.Version)
+			default:
+				addFn(BundleInfo, kind, sol.Name, sol.Id, "will be updated from version %d to %d", existing.Version, sol.Version)
+			}
+		}
+	}
+	checkSolutionsFn("playbook", bundle.PlaybooksById, target.PlaybooksById)
+	checkSolutionsFn("component", bundle.ComponentsById, target.ComponentsById)
+
+	// Applications are not part of the package and must already exist in the target
+	appsByUid := make(map[string]laneclient.Application, len(target.ApplicationsById))
+	for _, app := range target.ApplicationsById {
+		appsByUid[app.Uid] = app
+	}
+	for _, app := range bundle.ApplicationsById {
+		existing, exists := appsByUid[app.Uid]
+		if !exists {
+			addFn(BundleError, "application", app.Name, app.Id, "missing in target, record actions using it will fail")
+			continue
+		}
+		targetFields := make(map[string]bool, len(existing.Fields))
+		for _, field := range existing.Fields {
+			targetFields[field.Key] = true
+		}
+		missing := make([]string, 0)
+		for _, field := range app.Fields {
+			if !targetFields[field.Key] {
+				missing = append(missing, field.Key)
+			}
+		}
+		if len(missing) > 0 {
+			slices.Sort(missing)
+			addFn(BundleWarning, "application", app.Name, app.Id, "fields missing in target: %v", missing)
+		}
+	}
+
+	// Connectors must be installed in the target
+	connectorsByName := make(map[string]laneclient.Connector, len(target.ConnectorsById))
+	for _, conn := range target.ConnectorsById {
+		connectorsByName[conn.Meta.Manifest.Name] = conn
+	}
+	for _, conn := range bundle.ConnectorsById {
+		manifest := conn.Meta.Manifest
+		existing, exists := connectorsByName[manifest.Name]
+		if !exists {
+			addFn(BundleError, "connector", manifest.Title, conn.Id, "not installed in target (requires %s %s)", manifest.Name, manifest.Version)
+			continue
+		}
+		if existing.Meta.Manifest.Version != manifest.Version {
+			addFn(BundleWarning, "connector", manifest.Title, conn.Id, "version differs, bundle uses %s but target has %s", manifest.Version, existing.Meta.Manifest.Version)
+		}
+	}
+
+	// Assets hold tenant specific credentials, they have to be configured in the target
+	assetsByName := make(map[string]bool, len(target.AssetsById))
+	for _, asset := range target.AssetsById {
+		assetsByName[asset.Asset.Name] = true
+	}
+	for _, asset := range bundle.AssetsById {
+		if !assetsByName[asset.Asset.Name] {
+			addFn(BundleWarning, "asset", asset.Asset.Title, asset.Id, "missing in target, create it and link it to the connector actions after import")
+		}
+	}
+
+	// The bundle is read from maps, sort on every member so the report is the same on every run
+	slices.SortFunc(issues, func(a, b BundleIssue) int {
+		return cmp.Or(
+			cmp.Compare(b.Severity, a.Severity),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Id, b.Id),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+	return issues
+}
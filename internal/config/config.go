@@ -11,6 +11,12 @@ type Config struct {
 	SwimlaneRegion      string
 	SwimlaneAccountId   string
 	SwimlaneAccessToken string
+	SwimlaneTokenFile   string // Path to a file holding the access token, used instead of storing the token in the configuration.
+	SwimlaneUsername    string // Username for the login flow, used when no access token is configured. The password is never stored.
+	SwimlaneBaseURL     string // Full base URL of the instance (e.g. for on-prem deployments), overrides the region.
+	ProxyURL            string // Proxy for all API requests, the proxy from the environment is used when empty.
+	CABundle            string // Path to a PEM encoded CA bundle that is trusted in addition to the system roots.
+	InsecureSkipVerify  bool   // Disables TLS certificate verification, do not use in production.
 }
 
 // FQDN returns the fully qualified domain name for the Swimlane region.
@@ -18,6 +24,14 @@ func (c *Config) FQDN() string {
 	return fmt.Sprintf("%s.swimlane.app", c.SwimlaneRegion)
 }
 
+// BaseURL returns the base URL of the Swimlane instance.
+func (c *Config) BaseURL() string {
+	if c.SwimlaneBaseURL != "" {
+		return c.SwimlaneBaseURL
+	}
+	return "https://" + c.FQDN()
+}
+
 // GetConfigDir returns the directory where SwimPeek configuration files are stored.
 func GetConfigDir(createDir bool) (string, error) {
 	// Check if the SWIMPEEK_CONFIG_DIR environment variable is set
@@ -51,9 +65,9 @@ func GetConfigDir(createDir bool) (string, error) {
 	return cfgDir, nil
 }
 
-// ReadConfig reads the configuration from the specified directory.
-func ReadConfig(cfgDir string) (*Config, error) {
-	cfgPath := path.Join(cfgDir, "config.json")
+// ReadConfig reads the configuration of a profile from the specified directory.
+func ReadConfig(cfgDir string, profile string) (*Config, error) {
+	cfgPath := profilePath(cfgDir, profile)
 
 	// Check if the configuration file exists
 	if _, err := os.Stat(cfgPath); os.IsNotExist(err) {
@@ -83,9 +97,12 @@ func ReadConfig(cfgDir string) (*Config, error) {
 
 }
 
-// SaveConfig saves the configuration to a JSON file.
-func SaveConfig(cfgDir string, cfg *Config) error {
-	cfgPath := path.Join(cfgDir, "config.json")
+// SaveConfig saves the configuration of a profile to a JSON file.
+func SaveConfig(cfgDir string, profile string, cfg *Config) error {
+	cfgPath := profilePath(cfgDir, profile)
+	if err := os.MkdirAll(path.Dir(cfgPath), 0750); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
 
 	// Create or open the configuration file
 	file, err := os.Create(cfgPath)
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Environment variables that override the credentials from the configuration file, e.g. for CI jobs.
+const (
+	EnvToken     = "SWIMPEEK_TOKEN"      // Personal access token.
+	EnvTokenFile = "SWIMPEEK_TOKEN_FILE" // Path to a file holding the personal access token.
+	EnvUsername  = "SWIMPEEK_USERNAME"   // Username for the login flow.
+	EnvPassword  = "SWIMPEEK_PASSWORD"   // Password for the login flow.
+)
+
+// Credentials are used to authenticate with Swimlane, either Token or Username and Password are set.
+type Credentials struct {
+	Token    string
+	Username string
+	Password string
+	Source   string // Where the credentials were found, for logging.
+}
+
+// IsLogin returns true if the credentials are used for the username/password login flow.
+func (c Credentials) IsLogin() bool {
+	return c.Token == "" && c.Username != ""
+}
+
+// Credentials resolves the credentials to authenticate with, the first match wins:
+// SWIMPEEK_TOKEN, SWIMPEEK_TOKEN_FILE, SWIMPEEK_USERNAME, the access token, token file, or username from the configuration.
+// The password for the login flow is taken from SWIMPEEK_PASSWORD, it is left empty when not set.
+func (c *Config) Credentials() (Credentials, error) {
+	if token := strings.TrimSpace(os.Getenv(EnvToken)); token != "" {
+		return Credentials{Token: token, Source: EnvToken}, nil
+	}
+	if tokenFile := os.Getenv(EnvTokenFile); tokenFile != "" {
+		token, err := readTokenFile(tokenFile)
+		if err != nil {
+			return Credentials{}, err
+		}
+		return Credentials{Token: token, Source: EnvTokenFile}, nil
+	}
+	if username := os.Getenv(EnvUsername); username != "" {
+		return Credentials{Username: username, Password: os.Getenv(EnvPassword), Source: EnvUsername}, nil
+	}
+
+	if c.SwimlaneAccessToken != "" {
+		return Credentials{Token: c.SwimlaneAccessToken, Source: "config"}, nil
+	}
+	if c.SwimlaneTokenFile != "" {
+		token, err := readTokenFile(c.SwimlaneTokenFile)
+		if err != nil {
+			return Credentials{}, err
+		}
+		return Credentials{Token: token, Source: "config token file"}, nil
+	}
+	if c.SwimlaneUsername != "" {
+		return Credentials{Username: c.SwimlaneUsername, Password: os.Getenv(EnvPassword), Source: "config"}, nil
+	}
+
+	return Credentials{}, errors.New("no credentials configured; run 'swimpeek config' or set " + EnvToken)
+}
+
+// readTokenFile reads an access token from a file, surrounding whitespace is ignored.
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file is empty: %s", path)
+	}
+	return token, nil
+}
+
+// PromptPassword asks for the password of the login flow.
+func PromptPassword(username string) (string, error) {
+	password := ""
+	err := huh.NewInput().
+		Value(&password).
+		Title(fmt.Sprintf("Swimlane password for %s", username)).
+		EchoMode(huh.EchoModePassword).
+		Description(fmt.Sprintf("Set %s to skip this prompt.", EnvPassword)).
+		WithTheme(huh.ThemeDracula()).
+		Run()
+	if errors.Is(err, huh.ErrUserAborted) {
+		return "", fmt.Errorf("login was aborted by the user")
+	}
+	return password, err
+}
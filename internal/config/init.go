@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"regexp"
 
@@ -10,16 +11,47 @@ import (
 	"github.com/google/uuid"
 )
 
+// Authentication methods offered by the configuration form.
+const (
+	authToken     = "token"
+	authTokenFile = "token-file"
+	authLogin     = "login"
+)
+
 // initPrompts shows the user prompts for configuration initialization.
 func initPrompts(cfg *Config) error {
+	authMethod := authToken
+	if cfg.SwimlaneAccessToken == "" && cfg.SwimlaneTokenFile != "" {
+		authMethod = authTokenFile
+	} else if cfg.SwimlaneAccessToken == "" && cfg.SwimlaneUsername != "" {
+		authMethod = authLogin
+	}
+
 	confForm := huh.NewForm(
 		huh.NewGroup(
+			huh.NewInput().
+				Value(&cfg.SwimlaneBaseURL).
+				Title("Swimlane Base URL (optional)").
+				Placeholder("https://swimlane.example.com").
+				Description("Only required for on-prem deployments, leave empty for cloud-hosted instances.").
+				Validate(func(s string) error {
+					if s == "" {
+						return nil
+					}
+					if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+						return errors.New("please provide a valid URL (e.g., https://swimlane.example.com)")
+					}
+					return nil
+				}),
 			huh.NewInput().
 				Value(&cfg.SwimlaneRegion).
 				Title("Swimlane Region (e.g. us1, de1)").
 				Placeholder("us1").
-				Description("To find the region, visit any Swimlane tenant and look at the domain name.").
+				Description("To find the region, visit any Swimlane tenant and look at the domain name. Leave empty when a base URL is set.").
 				Validate(func(s string) error {
+					if s == "" && cfg.SwimlaneBaseURL != "" {
+						return nil
+					}
 					if !regexp.MustCompile(`(?i)^[a-z0-9]{3}$`).MatchString(s) {
 						return errors.New("please provide a valid Swimlane region (e.g., us1, de1)")
 					}
@@ -36,28 +68,78 @@ func initPrompts(cfg *Config) error {
 					}
 					return nil
 				}),
+			huh.NewSelect[string]().
+				Value(&authMethod).
+				Title("Authentication").
+				Description(fmt.Sprintf("CI jobs can override the configured credentials with %s, %s, or %s and %s.", EnvToken, EnvTokenFile, EnvUsername, EnvPassword)).
+				Options(
+					huh.NewOption("Personal access token", authToken),
+					huh.NewOption("Personal access token from a file", authTokenFile),
+					huh.NewOption("Username and password", authLogin),
+				),
+		),
+		huh.NewGroup(
 			huh.NewInput().
 				Value(&cfg.SwimlaneAccessToken).
 				Title("Swimlane Access Token").
 				EchoMode(huh.EchoModePassword).
 				Placeholder("your-access-token").
-				Description("To create a token, visit any Swimlane tenant and go to 'Profile & user settings' → 'Personal access token'.").
+				Description("To create a token, visit any Swimlane tenant and go to 'Profile & user settings' → 'Personal access token'. The token is stored in config.json.").
 				Validate(func(s string) error {
 					if len(s) != 64 {
 						return errors.New("please provide a valid Swimlane Access Token (exactly 64 characters)")
 					}
 					return nil
 				}),
-		),
+		).WithHideFunc(func() bool { return authMethod != authToken }),
+		huh.NewGroup(
+			huh.NewInput().
+				Value(&cfg.SwimlaneTokenFile).
+				Title("Access Token File").
+				Placeholder("/run/secrets/swimlane-token").
+				Description("Path to a file holding the personal access token, the token is read on every run.").
+				Validate(func(s string) error {
+					if _, err := readTokenFile(s); err != nil {
+						return fmt.Errorf("please provide a readable file with the access token: %w", err)
+					}
+					return nil
+				}),
+		).WithHideFunc(func() bool { return authMethod != authTokenFile }),
+		huh.NewGroup(
+			huh.NewInput().
+				Value(&cfg.SwimlaneUsername).
+				Title("Swimlane Username").
+				Description(fmt.Sprintf("The password is never stored, it is prompted for on every run unless %s is set.", EnvPassword)).
+				Validate(func(s string) error {
+					if s == "" {
+						return errors.New("please provide a username")
+					}
+					return nil
+				}),
+		).WithHideFunc(func() bool { return authMethod != authLogin }),
 	).WithTheme(huh.ThemeDracula()).WithLayout(huh.LayoutStack)
 
-	return confForm.Run()
+	if err := confForm.Run(); err != nil {
+		return err
+	}
+
+	// Only keep the credentials of the selected method, this removes a stored token when switching away from it
+	if authMethod != authToken {
+		cfg.SwimlaneAccessToken = ""
+	}
+	if authMethod != authTokenFile {
+		cfg.SwimlaneTokenFile = ""
+	}
+	if authMethod != authLogin {
+		cfg.SwimlaneUsername = ""
+	}
+	return nil
 }
 
-// InitConfig initializes the SwimPeek configuration.
-func InitConfig(cfgDir string) (*Config, error) {
+// InitConfig initializes the SwimPeek configuration of a profile.
+func InitConfig(cfgDir string, profile string) (*Config, error) {
 	// Load the existing configuration or create a fresh one
-	cfg, err := ReadConfig(cfgDir)
+	cfg, err := ReadConfig(cfgDir, profile)
 	if errors.Is(err, os.ErrNotExist) {
 		cfg = &Config{}
 	} else if err != nil {
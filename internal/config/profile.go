@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultProfile is the profile stored in config.json, it is used when no other profile is selected.
+const DefaultProfile = "default"
+
+// EnvProfile selects the profile for a single run, it takes precedence over the profile selected with 'swimpeek config use'.
+const EnvProfile = "SWIMPEEK_PROFILE"
+
+const (
+	profilesDir       = "profiles"       // Directory in the config directory holding the named profiles.
+	activeProfileFile = "active_profile" // File in the config directory holding the name of the selected profile.
+)
+
+var profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateProfileName returns an error if the name can't be used as a profile name.
+func ValidateProfileName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: only letters, digits, '-' and '_' are allowed", name)
+	}
+	return nil
+}
+
+// profilePath returns the path of the configuration file of a profile, the default profile is stored in config.json.
+func profilePath(cfgDir string, profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return path.Join(cfgDir, "config.json")
+	}
+	return path.Join(cfgDir, profilesDir, profile+".json")
+}
+
+// ResolveProfile returns the profile to use, the first match wins: the given name (e.g. from a flag), SWIMPEEK_PROFILE, the selected profile, or the default profile.
+func ResolveProfile(cfgDir string, profile string) (string, error) {
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if profile == "" {
+		active, err := ActiveProfile(cfgDir)
+		if err != nil {
+			return "", err
+		}
+		profile = active
+	}
+	if err := ValidateProfileName(profile); err != nil {
+		return "", err
+	}
+	return profile, nil
+}
+
+// ActiveProfile returns the profile selected with SetActiveProfile, or the default profile if none is selected.
+func ActiveProfile(cfgDir string) (string, error) {
+	data, err := os.ReadFile(path.Join(cfgDir, activeProfileFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultProfile, nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read active profile: %w", err)
+	}
+
+	profile := strings.TrimSpace(string(data))
+	if profile == "" {
+		return DefaultProfile, nil
+	}
+	return profile, nil
+}
+
+// SetActiveProfile selects the profile used when no profile is specified, the profile must exist.
+func SetActiveProfile(cfgDir string, profile string) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	if _, err := os.Stat(profilePath(cfgDir, profile)); err != nil {
+		return fmt.Errorf("profile %s does not exist: %w", profile, err)
+	}
+
+	if err := os.WriteFile(path.Join(cfgDir, activeProfileFile), []byte(profile+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write active profile: %w", err)
+	}
+	return nil
+}
+
+// ListProfiles returns the names of the existing profiles, sorted by name.
+func ListProfiles(cfgDir string) ([]string, error) {
+	profiles := make([]string, 0)
+	if _, err := os.Stat(profilePath(cfgDir, DefaultProfile)); err == nil {
+		profiles = append(profiles, DefaultProfile)
+	}
+
+	entries, err := os.ReadDir(path.Join(cfgDir, profilesDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	for _, entry := range entries {
+		name, isJSON := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !isJSON || ValidateProfileName(name) != nil || name == DefaultProfile {
+			continue
+		}
+		profiles = append(profiles, name)
+	}
+
+	slices.Sort(profiles)
+	return profiles, nil
+}
@@ -85,6 +85,7 @@ func createActionNode(warns *Warnings, graph *Graph, action laneclient.PlaybookA
 
 		// Connector reference
 		conActionNode := newNode(newMeta(actId, ConnectorActionNode, action.Title, action.Description))
+		linkActionAsset(warns, graph, conActionNode, action.Asset)
 		connectorRef, _, _ := strings.Cut(action.Action, ".")
 		if connectorRef == "" {
 			warns.Add(fmt.Errorf("connector action %s has no connector reference", actId))
@@ -138,6 +139,19 @@ func createActionNode(warns *Warnings, graph *Graph, action laneclient.PlaybookA
 	return newNode(newMeta(actId, UnknownActionNode, action.Title, action.Description)), fmt.Errorf("unknown action type %s", action.Type)
 }
 
+// linkActionAsset links a connector action to the asset it uses, if any.
+func linkActionAsset(warns *Warnings, graph *Graph, actNode *Node, assetId string) {
+	if assetId == "" {
+		return
+	}
+	assetNode, exists := graph.Resources.AssetsById[assetId]
+	if !exists {
+		warns.Add(fmt.Errorf("connector action %s references unknown asset %s", actNode.Meta.Id, assetId))
+		return
+	}
+	newEdge(assetNode, actNode, UsedByEdge, nil)
+}
+
 // chainActions chains actions starting from the given entrypoints.
 func chainActions(warns *Warnings, graph *Graph, source *Node, actions map[string]laneclient.PlaybookAction, entryPoints ...string) error {
 	actNodes, err := createActionNodes(warns, graph, actions)
@@ -2,6 +2,8 @@ package graph
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/just-oblivious/swimpeek/internal/lanedump"
@@ -42,6 +44,18 @@ func linkGraph(graph *Graph, laneState *lanedump.LaneState) ([]error, error) {
 	}
 	graph.Resources.TriggersById = trNodes
 
+	// Link legacy tasks to the applications and assets they use, and legacy triggers to the tasks they start.
+	linkLegacy(warns, graph, laneState)
+
+	// Link applications to the applications their reference fields point to.
+	linkApplications(warns, graph, laneState)
+
+	// Link workspaces, dashboards, and reports to applications.
+	linkWorkspaces(warns, graph, laneState)
+
+	// Link users, groups, and roles to the resources they have access to.
+	linkIdentities(warns, graph, laneState)
+
 	// Traverse the chain of actions in each workflows and link the resources they reference.
 	for wfId, wf := range laneState.WorkflowsById {
 		wfNode, exists := wfNodes[wfId]
@@ -225,6 +239,271 @@ func linkTriggers(warns *Warnings, graph *Graph, laneState *lanedump.LaneState,
 	return trNodes, nil
 }
 
+// linkLegacy links legacy integration tasks and triggers, they are not part of any workflow.
+func linkLegacy(warns *Warnings, graph *Graph, laneState *lanedump.LaneState) {
+	for taskId, task := range laneState.LegacyTasksById {
+		taskNode, exists := graph.Resources.LegacyTasksById[taskId]
+		if !exists {
+			warns.Add(fmt.Errorf("legacy task node %s not found", taskId))
+			continue
+		}
+
+		// A task reads from its application and may write to other applications through its outputs
+		appIds := []string{task.ApplicationId}
+		for _, output := range task.Outputs {
+			appIds = append(appIds, output.ApplicationId)
+		}
+		linked := make(map[string]bool)
+		for _, appId := range appIds {
+			if appId == "" || linked[appId] {
+				continue
+			}
+			linked[appId] = true
+			appNode, exists := graph.Resources.AppsById[appId]
+			if !exists {
+				warns.Add(fmt.Errorf("legacy task %s references unknown application %s", taskId, appId))
+				continue
+			}
+			newEdge(appNode, taskNode, AccessedByEdge, nil)
+		}
+
+		for _, assetId := range task.Action.AssetIds {
+			assetNode, exists := graph.Resources.AssetsById[assetId]
+			if !exists {
+				warns.Add(fmt.Errorf("legacy task %s references unknown asset %s", taskId, assetId))
+				continue
+			}
+			newEdge(assetNode, taskNode, UsedByEdge, nil)
+		}
+	}
+
+	for triggerId, trigger := range laneState.LegacyTriggersById {
+		trNode, exists := graph.Resources.LegacyTriggersById[triggerId]
+		if !exists {
+			warns.Add(fmt.Errorf("legacy trigger node %s not found", triggerId))
+			continue
+		}
+
+		// Button triggers are actions on the application like playbook buttons, other triggers are events
+		if trigger.ApplicationId != "" {
+			appNode, exists := graph.Resources.AppsById[trigger.ApplicationId]
+			if !exists {
+				warns.Add(fmt.Errorf("legacy trigger %s references unknown application %s", triggerId, trigger.ApplicationId))
+			} else if trigger.Type == "button" {
+				newEdge(appNode, trNode, HasActionEdge, nil)
+			} else {
+				newEdge(appNode, trNode, HasEventEdge, nil)
+			}
+		}
+
+		for _, taskId := range trigger.TaskIds {
+			taskNode, exists := graph.Resources.LegacyTasksById[taskId]
+			if !exists {
+				warns.Add(fmt.Errorf("legacy trigger %s references unknown legacy task %s", triggerId, taskId))
+				continue
+			}
+			newEdge(trNode, taskNode, TriggersTaskEdge, nil)
+		}
+	}
+}
+
+// linkApplications links applications through their reference fields, the edge meta describes the field.
+func linkApplications(warns *Warnings, graph *Graph, laneState *lanedump.LaneState) {
+	for appId, app := range laneState.ApplicationsById {
+		appNode, exists := graph.Resources.AppsById[appId]
+		if !exists {
+			warns.Add(fmt.Errorf("application node %s not found", appId))
+			continue
+		}
+		for _, field := range app.Fields {
+			if field.TargetId == "" {
+				continue
+			}
+			targetNode, exists := graph.Resources.AppsById[field.TargetId]
+			if !exists {
+				warns.Add(fmt.Errorf("field %s of application %s references unknown application %s", field.Key, appId, field.TargetId))
+				continue
+			}
+			fieldMeta := newMeta(field.Id, "", field.Name, field.Key)
+			newEdge(appNode, targetNode, ReferencesAppEdge, &fieldMeta)
+		}
+	}
+}
+
+// linkWorkspaces links workspaces to their applications and dashboards, and reports to the applications and dashboards they're used in.
+func linkWorkspaces(warns *Warnings, graph *Graph, laneState *lanedump.LaneState) {
+	for wsId, ws := range laneState.WorkspacesById {
+		wsNode, exists := graph.Resources.WorkspacesById[wsId]
+		if !exists {
+			warns.Add(fmt.Errorf("workspace node %s not found", wsId))
+			continue
+		}
+		for _, appId := range ws.Applications {
+			appNode, exists := graph.Resources.AppsById[appId]
+			if !exists {
+				warns.Add(fmt.Errorf("workspace %s references unknown application %s", wsId, appId))
+				continue
+			}
+			newEdge(wsNode, appNode, ContainsEdge, nil)
+		}
+		for _, dbId := range ws.Dashboards {
+			dbNode, exists := graph.Resources.DashboardsById[dbId]
+			if !exists {
+				warns.Add(fmt.Errorf("workspace %s references unknown dashboard %s", wsId, dbId))
+				continue
+			}
+			newEdge(wsNode, dbNode, ContainsEdge, nil)
+		}
+	}
+
+	for reportId, report := range laneState.ReportsById {
+		reportNode, exists := graph.Resources.ReportsById[reportId]
+		if !exists {
+			warns.Add(fmt.Errorf("report node %s not found", reportId))
+			continue
+		}
+		for _, appId := range report.ApplicationIds {
+			appNode, exists := graph.Resources.AppsById[appId]
+			if !exists {
+				warns.Add(fmt.Errorf("report %s references unknown application %s", reportId, appId))
+				continue
+			}
+			newEdge(appNode, reportNode, ReportedByEdge, nil)
+		}
+	}
+
+	for dbId, db := range laneState.DashboardsById {
+		dbNode, exists := graph.Resources.DashboardsById[dbId]
+		if !exists {
+			warns.Add(fmt.Errorf("dashboard node %s not found", dbId))
+			continue
+		}
+		for _, item := range db.Items {
+			if item.ReportId == "" {
+				continue // Widgets like HTML or text blocks don't reference a report.
+			}
+			reportNode, exists := graph.Resources.ReportsById[item.ReportId]
+			if !exists {
+				warns.Add(fmt.Errorf("dashboard %s references unknown report %s", dbId, item.ReportId))
+				continue
+			}
+			newEdge(dbNode, reportNode, DisplaysEdge, nil)
+		}
+	}
+}
+
+// linkIdentities links users to groups and roles, and roles to the applications, playbooks, and playbook buttons they grant access to.
+func linkIdentities(warns *Warnings, graph *Graph, laneState *lanedump.LaneState) {
+	// Memberships and role assignments are listed on both sides, the links are deduplicated and kept in the order they are found.
+	type link struct {
+		src, dst *Node
+		edgeType EdgeType
+	}
+	seen := make(map[link]bool)
+	links := make([]link, 0)
+	addLink := func(src, dst *Node, edgeType EdgeType) {
+		l := link{src, dst, edgeType}
+		if !seen[l] {
+			seen[l] = true
+			links = append(links, l)
+		}
+	}
+
+	for _, userId := range slices.Sorted(maps.Keys(laneState.UsersById)) {
+		user := laneState.UsersById[userId]
+		userNode := graph.Resources.UsersById[userId]
+		for _, ref := range user.Groups {
+			if groupNode, exists := graph.Resources.GroupsById[ref.Id]; exists {
+				addLink(userNode, groupNode, MemberOfEdge)
+			}
+		}
+		for _, ref := range user.Roles {
+			if roleNode, exists := graph.Resources.RolesById[ref.Id]; exists {
+				addLink(userNode, roleNode, HasRoleEdge)
+			}
+		}
+	}
+
+	for _, groupId := range slices.Sorted(maps.Keys(laneState.GroupsById)) {
+		group := laneState.GroupsById[groupId]
+		groupNode := graph.Resources.GroupsById[groupId]
+		for _, ref := range group.Users {
+			if userNode, exists := graph.Resources.UsersById[ref.Id]; exists {
+				addLink(userNode, groupNode, MemberOfEdge)
+			}
+		}
+		for _, ref := range group.Roles {
+			if roleNode, exists := graph.Resources.RolesById[ref.Id]; exists {
+				addLink(groupNode, roleNode, HasRoleEdge)
+			}
+		}
+	}
+
+	// Playbook buttons by the solution of the playbook they run.
+	buttonsBySolutionId := make(map[string][]*Node)
+	for _, task := range laneState.OrchestrationTasks {
+		btnNode, exists := graph.Resources.TriggersById[task.Id]
+		if !exists || btnNode.Meta.Type != PlaybookButtonNode {
+			continue
+		}
+		if wf, exists := laneState.WorkflowsById[task.PlaybookId]; exists && wf.Meta.SolutionId != "" {
+			buttonsBySolutionId[wf.Meta.SolutionId] = append(buttonsBySolutionId[wf.Meta.SolutionId], btnNode)
+		}
+	}
+
+	for _, roleId := range slices.Sorted(maps.Keys(laneState.RolesById)) {
+		role := laneState.RolesById[roleId]
+		roleNode := graph.Resources.RolesById[roleId]
+		for _, ref := range role.Users {
+			if userNode, exists := graph.Resources.UsersById[ref.Id]; exists {
+				addLink(userNode, roleNode, HasRoleEdge)
+			}
+		}
+		for _, ref := range role.Groups {
+			if groupNode, exists := graph.Resources.GroupsById[ref.Id]; exists {
+				addLink(groupNode, roleNode, HasRoleEdge)
+			}
+		}
+
+		// Permissions for other tenants or unsupported resource types are skipped.
+		for _, perm := range role.Permissions {
+			if perm.TenantId != "" && perm.TenantId != laneState.Tenant.Id {
+				continue
+			}
+			var resources map[string]*Node
+			switch perm.Type {
+			case "Application":
+				resources = graph.Resources.AppsById
+			case "Solution", "Playbook":
+				resources = graph.Resources.PlaybooksById
+			case "Component":
+				resources = graph.Resources.ComponentsById
+			default:
+				continue
+			}
+			resNode, exists := resources[perm.ResourceId]
+			if !exists {
+				warns.Add(fmt.Errorf("role %s grants access to unknown %s %s", roleId, strings.ToLower(perm.Type), perm.ResourceId))
+				continue
+			}
+			meta := newMeta(perm.ResourceId, resNode.Meta.Type, perm.Access.String(), "")
+			newEdge(roleNode, resNode, GrantsEdge, &meta)
+
+			// Execute access on the playbook grants running its buttons.
+			if resNode.Meta.Type == PlaybookNode && perm.Access.Has(laneclient.AccessExecute) {
+				for _, btnNode := range buttonsBySolutionId[perm.ResourceId] {
+					meta := newMeta(btnNode.Meta.Id, PlaybookButtonNode, perm.Access.String(), "through "+resNode.Meta.Label)
+					newEdge(roleNode, btnNode, GrantsEdge, &meta)
+				}
+			}
+		}
+	}
+
+	for _, l := range links {
+		newEdge(l.src, l.dst, l.edgeType, nil)
+	}
+}
+
 // linkWorkflowActions links the action chain in the workflow.
 func linkWorkflowActions(warns *Warnings, graph *Graph, wfPlaybook laneclient.Playbook, wfNode *Node) error {
 
@@ -15,6 +15,14 @@ const (
 	PlaybookNode    NodeType = "playbook"
 	ConnectorNode   NodeType = "connector"
 	WorkflowNode    NodeType = "workflow"
+	AssetNode       NodeType = "asset"
+	WorkspaceNode   NodeType = "workspace"
+	DashboardNode   NodeType = "dashboard"
+	ReportNode      NodeType = "report"
+	UserNode        NodeType = "user"
+	GroupNode       NodeType = "group"
+	RoleNode        NodeType = "role"
+	LegacyTaskNode  NodeType = "legacy_task"
 
 	// Trigger events
 	FlowEventNode      NodeType = "flow_event"
@@ -22,6 +30,7 @@ const (
 	PlaybookButtonNode NodeType = "playbook_button"
 	RecordEventNode    NodeType = "record_event"
 	CronEventNode      NodeType = "cron_event"
+	LegacyTriggerNode  NodeType = "legacy_trigger"
 
 	// Actions
 	UnknownActionNode        NodeType = "unknown_action"
@@ -53,7 +62,16 @@ const (
 	EmittedByEdge        EdgeType = "emitted_by"
 	CalledByEdge         EdgeType = "called_by"
 	AccessedByEdge       EdgeType = "accessed_by"
+	UsedByEdge           EdgeType = "used_by"
+	ContainsEdge         EdgeType = "contains"
+	ReportedByEdge       EdgeType = "reported_by"
+	DisplaysEdge         EdgeType = "displays"
+	ReferencesAppEdge    EdgeType = "references_app"
+	MemberOfEdge         EdgeType = "member_of"
+	HasRoleEdge          EdgeType = "has_role"
+	GrantsEdge           EdgeType = "grants"
 	TriggersWorkflowEdge EdgeType = "triggers_workflow"
+	TriggersTaskEdge     EdgeType = "triggers_task"
 	HasEventEdge         EdgeType = "has_event"
 	HasActionEdge        EdgeType = "has_action"
 	WorkflowEdge         EdgeType = "workflow"
@@ -67,20 +85,38 @@ const (
 )
 
 type ResourceNodes struct {
-	AppsById       map[string]*Node
-	ComponentsById map[string]*Node
-	PlaybooksById  map[string]*Node
-	ConnectorsById map[string]*Node
-	TriggersById   map[string]*Node
+	AppsById           map[string]*Node
+	ComponentsById     map[string]*Node
+	PlaybooksById      map[string]*Node
+	ConnectorsById     map[string]*Node
+	AssetsById         map[string]*Node
+	WorkspacesById     map[string]*Node
+	DashboardsById     map[string]*Node
+	ReportsById        map[string]*Node
+	UsersById          map[string]*Node
+	GroupsById         map[string]*Node
+	RolesById          map[string]*Node
+	TriggersById       map[string]*Node
+	LegacyTasksById    map[string]*Node
+	LegacyTriggersById map[string]*Node
 }
 
 // createNodes creates nodes for top-level resources in the dump.
 func createNodes(laneState *lanedump.LaneState) *ResourceNodes {
 	groups := &ResourceNodes{
-		AppsById:       createAppNodes(laneState),
-		ComponentsById: createComponentNodes(laneState),
-		PlaybooksById:  createPlaybookNodes(laneState),
-		ConnectorsById: createConnectorNodes(laneState),
+		AppsById:           createAppNodes(laneState),
+		ComponentsById:     createComponentNodes(laneState),
+		PlaybooksById:      createPlaybookNodes(laneState),
+		ConnectorsById:     createConnectorNodes(laneState),
+		AssetsById:         createAssetNodes(laneState),
+		WorkspacesById:     createWorkspaceNodes(laneState),
+		DashboardsById:     createDashboardNodes(laneState),
+		ReportsById:        createReportNodes(laneState),
+		UsersById:          createUserNodes(laneState),
+		GroupsById:         createGroupNodes(laneState),
+		RolesById:          createRoleNodes(laneState),
+		LegacyTasksById:    createLegacyTaskNodes(laneState),
+		LegacyTriggersById: createLegacyTriggerNodes(laneState),
 	}
 
 	return groups
@@ -134,3 +170,119 @@ func createConnectorNodes(state *lanedump.LaneState) map[string]*Node {
 
 	return nodes
 }
+
+// createAssetNodes creates nodes for each asset.
+func createAssetNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.AssetsById))
+
+	for assetId, asset := range state.AssetsById {
+		label := asset.Asset.Title
+		if label == "" {
+			label = asset.Asset.Name
+		}
+		meta := newMeta(assetId, AssetNode, label, asset.Asset.Description)
+		nodes[assetId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createWorkspaceNodes creates nodes for each workspace.
+func createWorkspaceNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.WorkspacesById))
+
+	for wsId, ws := range state.WorkspacesById {
+		meta := newMeta(wsId, WorkspaceNode, ws.Name, ws.Description)
+		nodes[wsId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createDashboardNodes creates nodes for each dashboard.
+func createDashboardNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.DashboardsById))
+
+	for dbId, db := range state.DashboardsById {
+		meta := newMeta(dbId, DashboardNode, db.Name, db.Description)
+		nodes[dbId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createReportNodes creates nodes for each report.
+func createReportNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.ReportsById))
+
+	for reportId, report := range state.ReportsById {
+		meta := newMeta(reportId, ReportNode, report.Name, "")
+		nodes[reportId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createUserNodes creates nodes for each user.
+func createUserNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.UsersById))
+
+	for userId, user := range state.UsersById {
+		label := user.DisplayName
+		if label == "" {
+			label = user.UserName
+		}
+		meta := newMeta(userId, UserNode, label, user.Email)
+		nodes[userId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createGroupNodes creates nodes for each group.
+func createGroupNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.GroupsById))
+
+	for groupId, group := range state.GroupsById {
+		meta := newMeta(groupId, GroupNode, group.Name, group.Description)
+		nodes[groupId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createRoleNodes creates nodes for each role.
+func createRoleNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.RolesById))
+
+	for roleId, role := range state.RolesById {
+		meta := newMeta(roleId, RoleNode, role.Name, role.Description)
+		nodes[roleId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createLegacyTaskNodes creates nodes for each legacy integration task.
+func createLegacyTaskNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.LegacyTasksById))
+
+	for taskId, task := range state.LegacyTasksById {
+		meta := newMeta(taskId, LegacyTaskNode, task.Name, task.Description)
+		nodes[taskId] = newNode(meta)
+	}
+
+	return nodes
+}
+
+// createLegacyTriggerNodes creates nodes for each legacy trigger.
+func createLegacyTriggerNodes(state *lanedump.LaneState) map[string]*Node {
+	nodes := make(map[string]*Node, len(state.LegacyTriggersById))
+
+	for triggerId, trigger := range state.LegacyTriggersById {
+		meta := newMeta(triggerId, LegacyTriggerNode, trigger.Name, trigger.Type)
+		nodes[triggerId] = newNode(meta)
+	}
+
+	return nodes
+}
@@ -0,0 +1,221 @@
+package lanediff
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// Members that hold tenant-local ids or timestamps, these always differ between tenants.
+var (
+	driftSkipSolution    = []string{"id", "createdDate", "modifiedDate", "playbookIds", "playbookId", "referencedComponents"}
+	driftSkipApplication = []string{"id", "createdDate", "modifiedDate", "workspaces", "trackingFieldId", "fields"}
+	driftSkipField       = []string{"id", "targetId"}
+	driftSkipTask        = []string{"id", "applicationId", "playbookId"}
+)
+
+// tenantRefs translates the tenant-local ids of a dump to keys that are stable across tenants.
+type tenantRefs struct {
+	uids      map[string]string // Uid of playbooks, components, and applications by id.
+	workflows map[string]string // Uid of the owning solution and the workflow name by workflow id.
+}
+
+func newTenantRefs(laneState *lanedump.LaneState) tenantRefs {
+	refs := tenantRefs{uids: make(map[string]string), workflows: make(map[string]string)}
+	for _, solutions := range []map[string]laneclient.OrchestrationSolution{laneState.PlaybooksById, laneState.ComponentsById} {
+		for id, solution := range solutions {
+			refs.uids[id] = solution.Uid
+			for workflowId, workflow := range solutionWorkflows(solution, laneState.WorkflowsById) {
+				refs.workflows[workflowId] = solution.Uid + "/" + workflowKey(workflow)
+			}
+		}
+	}
+	for id, app := range laneState.ApplicationsById {
+		refs.uids[id] = app.Uid
+	}
+	return refs
+}
+
+// uid returns the stable key of a tenant-local id, unknown ids are returned as is.
+func (r tenantRefs) uid(id string) string {
+	if uid, exists := r.uids[id]; exists {
+		return uid
+	}
+	if key, exists := r.workflows[id]; exists {
+		return key
+	}
+	return id
+}
+
+// uidList translates a list of tenant-local ids, sorted so the order doesn't matter.
+func (r tenantRefs) uidList(ids []string) []string {
+	uids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		uids = append(uids, r.uid(id))
+	}
+	slices.Sort(uids)
+	return uids
+}
+
+// DriftStates compares the content of two tenants, e.g. a development and production tenant sharing content through solution packages.
+// Playbooks, components, applications, and orchestration tasks are matched by their Uid, fields by the Uid of their application and their key,
+// connectors by their name, and sensors by their name. Workflows of matched solutions are matched by name.
+// Resources without a Uid, and resources sharing a Uid or name within a tenant, can't be matched and are reported as warnings.
+func DriftStates(old *lanedump.LaneState, new *lanedump.LaneState) (StateDiff, []error) {
+	oldRefs, newRefs := newTenantRefs(old), newTenantRefs(new)
+	warns := make([]error, 0)
+
+	oldPlaybooks, oldComponents := byUid(old.PlaybooksById, solutionUid, &warns), byUid(old.ComponentsById, solutionUid, &warns)
+	newPlaybooks, newComponents := byUid(new.PlaybooksById, solutionUid, &warns), byUid(new.ComponentsById, solutionUid, &warns)
+	oldApps, newApps := byUid(old.ApplicationsById, applicationUid, &warns), byUid(new.ApplicationsById, applicationUid, &warns)
+
+	compareSolutionFn := func(o laneclient.OrchestrationSolution, n laneclient.OrchestrationSolution) []string {
+		fields := changedMembers("", o, n, driftSkipSolution...)
+		if o.Version != n.Version {
+			fields = replaceMember(fields, "version", fmt.Sprintf("version: %d ≠ %d", o.Version, n.Version))
+		}
+		if added, removed := diffIds(oldRefs.uidList(o.ReferencedComponents), newRefs.uidList(n.ReferencedComponents)); len(added)+len(removed) > 0 {
+			fields = append(fields, "referencedComponents"+formatTargets(added, removed))
+		}
+		return fields
+	}
+	driftSolutions := func(oldSolutions map[string]laneclient.OrchestrationSolution, newSolutions map[string]laneclient.OrchestrationSolution) []ResourceChange {
+		changes := diffResources(oldSolutions, newSolutions, solutionName, compareSolutionFn)
+		for _, uid := range slices.Sorted(maps.Keys(newSolutions)) {
+			oldSolution, exists := oldSolutions[uid]
+			if !exists {
+				continue
+			}
+			workflows := DiffWorkflowSets(workflowsByKey(solutionWorkflows(oldSolution, old.WorkflowsById), &warns), workflowsByKey(solutionWorkflows(newSolutions[uid], new.WorkflowsById), &warns))
+			if len(workflows) == 0 {
+				continue
+			}
+			// Solutions present on both sides are only listed when modified
+			if idx := slices.IndexFunc(changes, func(c ResourceChange) bool { return c.Id == uid }); idx >= 0 {
+				changes[idx].Workflows = workflows
+				continue
+			}
+			changes = append(changes, ResourceChange{Kind: ResourceModified, Id: uid, Name: newSolutions[uid].Name, Workflows: workflows})
+		}
+		sortChanges(changes)
+		return changes
+	}
+
+	compareApplicationFn := func(o laneclient.Application, n laneclient.Application) []string {
+		fields := changedMembers("", o, n, driftSkipApplication...)
+		if o.Version != n.Version {
+			fields = replaceMember(fields, "version", fmt.Sprintf("version: %d ≠ %d", o.Version, n.Version))
+		}
+		return fields
+	}
+	compareFieldFn := func(o appField, n appField) []string {
+		fields := changedMembers("", o.field, n.field, driftSkipField...)
+		if oldRefs.uid(o.field.TargetId) != newRefs.uid(n.field.TargetId) {
+			fields = append(fields, "targetId")
+		}
+		return fields
+	}
+	compareTaskFn := func(o laneclient.OrchestrationTask, n laneclient.OrchestrationTask) []string {
+		fields := changedMembers("", o, n, driftSkipTask...)
+		if oldRefs.uid(o.ApplicationId) != newRefs.uid(n.ApplicationId) {
+			fields = append(fields, "application")
+		}
+		if oldRefs.uid(o.PlaybookId) != newRefs.uid(n.PlaybookId) {
+			fields = append(fields, "playbook")
+		}
+		return fields
+	}
+
+	return StateDiff{
+		MatchedBy:          MatchByUid,
+		Old:                dumpRef(old),
+		New:                dumpRef(new),
+		Playbooks:          driftSolutions(oldPlaybooks, newPlaybooks),
+		Components:         driftSolutions(oldComponents, newComponents),
+		Applications:       diffResources(oldApps, newApps, applicationName, compareApplicationFn),
+		Fields:             diffResources(fieldsByKey(oldApps), fieldsByKey(newApps), fieldName, compareFieldFn),
+		Connectors:         diffResources(byName(old.ConnectorsById, connectorKey, &warns), byName(new.ConnectorsById, connectorKey, &warns), connectorName, compareConnectors),
+		Sensors:            diffResources(byName(old.SensorsById, sensorKey, &warns), byName(new.SensorsById, sensorKey, &warns), sensorName, compareDriftSensors),
+		OrchestrationTasks: diffResources(tasksByUid(old.OrchestrationTasks, &warns), tasksByUid(new.OrchestrationTasks, &warns), taskName, compareTaskFn),
+	}, warns
+}
+
+// byUid rekeys resources by their Uid, resources without a Uid are skipped with a warning.
+// Resources sharing a Uid can't be told apart, the one with the lowest id is kept and the others are skipped with a warning.
+func byUid[T any](resources map[string]T, uidFn func(T) (string, string), warns *[]error) map[string]T {
+	rekeyed := make(map[string]T, len(resources))
+	keptIds := make(map[string]string, len(resources))
+	for _, id := range slices.Sorted(maps.Keys(resources)) {
+		uid, name := uidFn(resources[id])
+		if uid == "" {
+			*warns = append(*warns, fmt.Errorf("%s (%s) has no uid and can't be matched", name, id))
+			continue
+		}
+		if keptId, exists := keptIds[uid]; exists {
+			*warns = append(*warns, fmt.Errorf("%s (%s) has the same uid %s as %s and can't be matched", name, id, uid, keptId))
+			continue
+		}
+		keptIds[uid] = id
+		rekeyed[uid] = resources[id]
+	}
+	return rekeyed
+}
+
+// byName rekeys resources by a name that is shared across tenants.
+// Resources sharing a name can't be told apart, the one with the lowest id is kept and the others are skipped with a warning.
+func byName[T any](resources map[string]T, keyFn func(T) string, warns *[]error) map[string]T {
+	rekeyed := make(map[string]T, len(resources))
+	keptIds := make(map[string]string, len(resources))
+	for _, id := range slices.Sorted(maps.Keys(resources)) {
+		key := keyFn(resources[id])
+		if keptId, exists := keptIds[key]; exists {
+			*warns = append(*warns, fmt.Errorf("%q (%s) has the same name as %s and can't be matched", key, id, keptId))
+			continue
+		}
+		keptIds[key] = id
+		rekeyed[key] = resources[id]
+	}
+	return rekeyed
+}
+
+// tasksByUid returns the orchestration tasks keyed by Uid.
+func tasksByUid(tasks []laneclient.OrchestrationTask, warns *[]error) map[string]laneclient.OrchestrationTask {
+	return byUid(tasksById(tasks), func(t laneclient.OrchestrationTask) (string, string) { return t.Uid, t.Name }, warns)
+}
+
+// fieldsByKey returns the fields of applications keyed by application Uid and field key.
+func fieldsByKey(apps map[string]laneclient.Application) map[string]appField {
+	fields := make(map[string]appField)
+	for uid, app := range apps {
+		for _, field := range app.Fields {
+			fields[uid+"/"+field.Key] = appField{app: app.Name, field: field}
+		}
+	}
+	return fields
+}
+
+// workflowsByKey rekeys the workflows of a solution by name, workflow ids differ between tenants.
+func workflowsByKey(workflows map[string]laneclient.Workflow, warns *[]error) map[string]laneclient.Workflow {
+	return byName(workflows, workflowKey, warns)
+}
+
+// workflowKey returns the name of a workflow, falling back to the title.
+func workflowKey(w laneclient.Workflow) string {
+	if w.Playbook.Name != "" {
+		return w.Playbook.Name
+	}
+	return w.Playbook.Title
+}
+
+func solutionUid(s laneclient.OrchestrationSolution) (string, string) { return s.Uid, s.Name }
+func applicationUid(a laneclient.Application) (string, string)        { return a.Uid, a.Name }
+func connectorKey(c laneclient.Connector) string                      { return c.Meta.Manifest.Name }
+func sensorKey(s laneclient.Sensor) string                            { return s.Meta.Name }
+
+// compareDriftSensors ignores the playbook references and the endpoint URL, these are tenant-specific.
+func compareDriftSensors(old laneclient.Sensor, new laneclient.Sensor) []string {
+	return append(changedMembers("meta.", old.Meta, new.Meta, "emittedByPlaybooks", "triggeredPlaybooks"), changedMembers("sensor.", old.Sensor, new.Sensor, "url")...)
+}
@@ -0,0 +1,50 @@
+package lanediff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+func TestByNameDuplicates(t *testing.T) {
+	connector := func(id string, name string) laneclient.Connector {
+		c := laneclient.Connector{Id: id}
+		c.Meta.Manifest.Name = name
+		return c
+	}
+	connectors := map[string]laneclient.Connector{
+		"c3": connector("c3", "http"),
+		"c1": connector("c1", "http"),
+		"c2": connector("c2", "http"),
+	}
+
+	// The kept resource must not depend on the map iteration order
+	for range 20 {
+		var warns []error
+		rekeyed := byName(connectors, connectorKey, &warns)
+		if rekeyed["http"].Id != "c1" {
+			t.Fatalf("kept %s, want c1", rekeyed["http"].Id)
+		}
+		if len(warns) != 2 || !strings.Contains(warns[0].Error(), "c2") || !strings.Contains(warns[1].Error(), "c3") {
+			t.Fatalf("unexpected warnings: %v", warns)
+		}
+	}
+}
+
+func TestByUidDuplicates(t *testing.T) {
+	apps := map[string]laneclient.Application{
+		"a2": {Id: "a2", Uid: "u1", Name: "Copy"},
+		"a1": {Id: "a1", Uid: "u1", Name: "Original"},
+		"a3": {Id: "a3", Name: "Without uid"},
+	}
+
+	var warns []error
+	rekeyed := byUid(apps, applicationUid, &warns)
+	if len(rekeyed) != 1 || rekeyed["u1"].Id != "a1" {
+		t.Fatalf("unexpected resources: %v", rekeyed)
+	}
+	if len(warns) != 2 {
+		t.Fatalf("warnings = %v, want one for the duplicate and one for the missing uid", warns)
+	}
+}
@@ -0,0 +1,138 @@
+package lanediff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Report formats.
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the supported report formats.
+var Formats = []string{FormatText, FormatJSON, FormatMarkdown}
+
+// kindSymbols are the prefixes of added, removed, and modified resources, matching the workflow summaries.
+var kindSymbols = map[string]string{
+	ResourceAdded:    "+",
+	ResourceRemoved:  "-",
+	ResourceModified: "~",
+}
+
+// WriteReport writes the differences in one of the report formats.
+func (d StateDiff) WriteReport(w io.Writer, format string) error {
+	switch format {
+	case FormatText:
+		return d.writeText(w)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case FormatMarkdown:
+		return d.writeMarkdown(w)
+	}
+	return fmt.Errorf("unknown report format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+// kindLabel describes the kind of a resource change, changes between tenants are described by the side holding the resource.
+func (d StateDiff) kindLabel(kind string) string {
+	if d.MatchedBy != MatchByUid {
+		return kind
+	}
+	switch kind {
+	case ResourceAdded:
+		return "only in " + d.New.Tenant
+	case ResourceRemoved:
+		return "only in " + d.Old.Tenant
+	}
+	return "differs"
+}
+
+// describe returns the one-line description of a resource change.
+func (c ResourceChange) describe() string {
+	line := fmt.Sprintf("%s %s (%s)", kindSymbols[c.Kind], c.Name, c.Id)
+	if len(c.Changes) > 0 {
+		line += ": " + strings.Join(c.Changes, ", ")
+	}
+	return line
+}
+
+func (d StateDiff) writeText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Comparing %s (%s) with %s (%s)\n", d.Old.Tenant, d.Old.TimeStamp.Format(time.DateTime), d.New.Tenant, d.New.TimeStamp.Format(time.DateTime))
+	if d.MatchedBy == MatchByUid {
+		fmt.Fprintf(&b, "Matched by uid: - %s, + %s, ~ differs\n", d.kindLabel(ResourceRemoved), d.kindLabel(ResourceAdded))
+	}
+	if d.IsEmpty() {
+		b.WriteString("No differences\n")
+	}
+
+	for _, section := range d.Sections() {
+		if len(section.Changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s (%d)\n", section.Label, len(section.Changes))
+		for _, change := range section.Changes {
+			fmt.Fprintf(&b, "  %s\n", change.describe())
+			for _, workflow := range change.Workflows {
+				fmt.Fprintf(&b, "    ▶ %s\n", workflow.Title)
+				for _, line := range workflow.Summary() {
+					fmt.Fprintf(&b, "      %s\n", line)
+				}
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (d StateDiff) writeMarkdown(w io.Writer) error {
+	var b strings.Builder
+	if d.MatchedBy == MatchByUid {
+		fmt.Fprintf(&b, "# Drift between %s and %s\n\n", markdownEscape(d.Old.Tenant), markdownEscape(d.New.Tenant))
+		fmt.Fprintf(&b, "Comparing the dumps of %s and %s, resources are matched by uid.\n", d.Old.TimeStamp.Format(time.DateTime), d.New.TimeStamp.Format(time.DateTime))
+	} else {
+		fmt.Fprintf(&b, "# Changes in %s\n\n", markdownEscape(d.New.Tenant))
+		fmt.Fprintf(&b, "Comparing the dump of %s with the dump of %s.\n", d.Old.TimeStamp.Format(time.DateTime), d.New.TimeStamp.Format(time.DateTime))
+	}
+	if d.IsEmpty() {
+		b.WriteString("\nNo differences.\n")
+	}
+
+	for _, section := range d.Sections() {
+		if len(section.Changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", section.Label, len(section.Changes))
+		for _, change := range section.Changes {
+			fmt.Fprintf(&b, "- **%s** %s (`%s`)", d.kindLabel(change.Kind), markdownEscape(change.Name), change.Id)
+			if len(change.Changes) > 0 {
+				fmt.Fprintf(&b, ": %s", markdownEscape(strings.Join(change.Changes, ", ")))
+			}
+			b.WriteString("\n")
+			for _, workflow := range change.Workflows {
+				fmt.Fprintf(&b, "  - workflow *%s*\n", markdownEscape(workflow.Title))
+				b.WriteString("    ```diff\n")
+				for _, line := range workflow.Summary() {
+					fmt.Fprintf(&b, "    %s\n", line)
+				}
+				b.WriteString("    ```\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape escapes the characters that would otherwise be rendered as formatting.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`).Replace(s)
+}
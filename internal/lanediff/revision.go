@@ -0,0 +1,27 @@
+package lanediff
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// DiffWorkflowSets compares two sets of workflows keyed by workflow id, workflows missing on one side are compared to an empty workflow.
+// Only workflows with differences are returned.
+func DiffWorkflowSets(old map[string]laneclient.Workflow, new map[string]laneclient.Workflow) []WorkflowDiff {
+	diffs := make([]WorkflowDiff, 0)
+	for _, workflowId := range slices.Sorted(maps.Keys(mergeKeys(old, new))) {
+		diff := DiffWorkflows(old[workflowId], new[workflowId])
+		if !diff.IsEmpty() {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// DiffRevisions compares the workflows of two revisions of a playbook or component.
+func DiffRevisions(old lanedump.SolutionRevision, new lanedump.SolutionRevision) []WorkflowDiff {
+	return DiffWorkflowSets(old.Workflows, new.Workflows)
+}
@@ -0,0 +1,290 @@
+package lanediff
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// Change kinds of a resource.
+const (
+	ResourceAdded    = "added"
+	ResourceRemoved  = "removed"
+	ResourceModified = "modified"
+)
+
+// How the resources of two dumps are matched.
+const (
+	MatchById  = "id"  // Tenant-local ids, for dumps of the same tenant.
+	MatchByUid = "uid" // Uids that are kept when content is promoted, for dumps of different tenants.
+)
+
+// ResourceChange describes how a resource differs between two dumps.
+type ResourceChange struct {
+	Kind      string         `json:"kind"` // added, removed, or modified.
+	Id        string         `json:"id"`
+	Name      string         `json:"name"`
+	Changes   []string       `json:"changes,omitempty"`   // Changed properties of a modified resource, e.g. "name" or "version: 3 → 4".
+	Workflows []WorkflowDiff `json:"workflows,omitempty"` // Workflow changes of a modified playbook or component.
+}
+
+// DumpRef identifies one side of a comparison.
+type DumpRef struct {
+	Tenant    string    `json:"tenant"`
+	TenantId  string    `json:"tenantId"`
+	TimeStamp time.Time `json:"timestamp"`
+}
+
+// StateDiff holds the differences between two dumps, grouped by resource type.
+type StateDiff struct {
+	MatchedBy          string           `json:"matchedBy"` // id or uid, the Id of the resource changes holds the matched key.
+	Old                DumpRef          `json:"old"`
+	New                DumpRef          `json:"new"`
+	Playbooks          []ResourceChange `json:"playbooks"`
+	Components         []ResourceChange `json:"components"`
+	Applications       []ResourceChange `json:"applications"`
+	Fields             []ResourceChange `json:"fields"`
+	Connectors         []ResourceChange `json:"connectors"`
+	Sensors            []ResourceChange `json:"sensors"`
+	OrchestrationTasks []ResourceChange `json:"orchestrationTasks"`
+}
+
+// Sections returns the resource changes with a label per resource type, in report order.
+func (d StateDiff) Sections() []Section {
+	return []Section{
+		{Label: "Playbooks", Changes: d.Playbooks},
+		{Label: "Components", Changes: d.Components},
+		{Label: "Applications", Changes: d.Applications},
+		{Label: "Fields", Changes: d.Fields},
+		{Label: "Connectors", Changes: d.Connectors},
+		{Label: "Sensors", Changes: d.Sensors},
+		{Label: "Orchestration tasks", Changes: d.OrchestrationTasks},
+	}
+}
+
+// Section is the list of changes of a single resource type.
+type Section struct {
+	Label   string
+	Changes []ResourceChange
+}
+
+// IsEmpty returns true if both dumps are equivalent.
+func (d StateDiff) IsEmpty() bool {
+	for _, section := range d.Sections() {
+		if len(section.Changes) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffStates compares two dumps of the same tenant, resources are matched by their id.
+func DiffStates(old *lanedump.LaneState, new *lanedump.LaneState) StateDiff {
+	return StateDiff{
+		MatchedBy:          MatchById,
+		Old:                dumpRef(old),
+		New:                dumpRef(new),
+		Playbooks:          diffSolutions(old.PlaybooksById, new.PlaybooksById, old.WorkflowsById, new.WorkflowsById),
+		Components:         diffSolutions(old.ComponentsById, new.ComponentsById, old.WorkflowsById, new.WorkflowsById),
+		Applications:       diffResources(old.ApplicationsById, new.ApplicationsById, applicationName, compareApplications),
+		Fields:             diffResources(appFields(old.ApplicationsById), appFields(new.ApplicationsById), fieldName, compareFields),
+		Connectors:         diffResources(old.ConnectorsById, new.ConnectorsById, connectorName, compareConnectors),
+		Sensors:            diffResources(old.SensorsById, new.SensorsById, sensorName, compareSensors),
+		OrchestrationTasks: diffResources(tasksById(old.OrchestrationTasks), tasksById(new.OrchestrationTasks), taskName, compareTasks),
+	}
+}
+
+func dumpRef(laneState *lanedump.LaneState) DumpRef {
+	return DumpRef{Tenant: laneState.Tenant.Name, TenantId: laneState.Tenant.Id, TimeStamp: laneState.TimeStamp}
+}
+
+// diffResources compares two sets of resources keyed by id, compareFn returns the changed properties of a resource present in both.
+func diffResources[T any](old map[string]T, new map[string]T, nameFn func(T) string, compareFn func(T, T) []string) []ResourceChange {
+	changes := make([]ResourceChange, 0)
+	for _, id := range slices.Sorted(maps.Keys(mergeKeys(old, new))) {
+		oldRes, inOld := old[id]
+		newRes, inNew := new[id]
+		switch {
+		case !inOld:
+			changes = append(changes, ResourceChange{Kind: ResourceAdded, Id: id, Name: nameFn(newRes)})
+		case !inNew:
+			changes = append(changes, ResourceChange{Kind: ResourceRemoved, Id: id, Name: nameFn(oldRes)})
+		default:
+			if fields := compareFn(oldRes, newRes); len(fields) > 0 {
+				changes = append(changes, ResourceChange{Kind: ResourceModified, Id: id, Name: nameFn(newRes), Changes: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// diffSolutions compares two sets of playbooks or components including the workflows they reference.
+func diffSolutions(old map[string]laneclient.OrchestrationSolution, new map[string]laneclient.OrchestrationSolution, oldWorkflows map[string]laneclient.Workflow, newWorkflows map[string]laneclient.Workflow) []ResourceChange {
+	changes := diffResources(old, new, solutionName, compareSolutions)
+	modified := make(map[string]bool)
+	for idx, change := range changes {
+		if change.Kind == ResourceModified {
+			changes[idx].Workflows = DiffWorkflowSets(solutionWorkflows(old[change.Id], oldWorkflows), solutionWorkflows(new[change.Id], newWorkflows))
+			modified[change.Id] = true
+		}
+	}
+
+	// Solutions can be unchanged while their workflows are modified, e.g. by enabling a workflow
+	for _, id := range slices.Sorted(maps.Keys(new)) {
+		oldSolution, exists := old[id]
+		if !exists || modified[id] {
+			continue
+		}
+		workflows := DiffWorkflowSets(solutionWorkflows(oldSolution, oldWorkflows), solutionWorkflows(new[id], newWorkflows))
+		if len(workflows) > 0 {
+			changes = append(changes, ResourceChange{Kind: ResourceModified, Id: id, Name: new[id].Name, Workflows: workflows})
+		}
+	}
+	sortChanges(changes)
+	return changes
+}
+
+// sortChanges sorts resource changes by id.
+func sortChanges(changes []ResourceChange) {
+	slices.SortStableFunc(changes, func(a, b ResourceChange) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+}
+
+// solutionWorkflows returns the workflows referenced by a playbook or component.
+func solutionWorkflows(solution laneclient.OrchestrationSolution, workflows map[string]laneclient.Workflow) map[string]laneclient.Workflow {
+	referenced := make(map[string]laneclient.Workflow)
+	for _, workflowId := range append(slices.Clone(solution.PlaybookIds), solution.PlaybookId) {
+		if workflow, exists := workflows[workflowId]; exists {
+			referenced[workflowId] = workflow
+		}
+	}
+	return referenced
+}
+
+// appFields returns the fields of all applications keyed by application and field id.
+func appFields(apps map[string]laneclient.Application) map[string]appField {
+	fields := make(map[string]appField)
+	for appId, app := range apps {
+		for _, field := range app.Fields {
+			fields[appId+"/"+field.Id] = appField{app: app.Name, field: field}
+		}
+	}
+	return fields
+}
+
+// appField is an application field along with the name of its application.
+type appField struct {
+	app   string
+	field laneclient.ApplicationField
+}
+
+// tasksById returns the orchestration tasks keyed by id.
+func tasksById(tasks []laneclient.OrchestrationTask) map[string]laneclient.OrchestrationTask {
+	byId := make(map[string]laneclient.OrchestrationTask, len(tasks))
+	for _, task := range tasks {
+		byId[task.Id] = task
+	}
+	return byId
+}
+
+func solutionName(s laneclient.OrchestrationSolution) string { return s.Name }
+func applicationName(a laneclient.Application) string        { return a.Name }
+func fieldName(f appField) string                            { return f.app + " › " + f.field.Name }
+func connectorName(c laneclient.Connector) string            { return c.Meta.Manifest.Title }
+func sensorName(s laneclient.Sensor) string                  { return s.Meta.Title }
+func taskName(t laneclient.OrchestrationTask) string         { return t.Name }
+
+// compareSolutions ignores the modification date and the workflow references, workflows are compared separately.
+func compareSolutions(old laneclient.OrchestrationSolution, new laneclient.OrchestrationSolution) []string {
+	fields := changedMembers("", old, new, "modifiedDate", "createdDate", "playbookIds", "playbookId")
+	if old.Version != new.Version {
+		fields = replaceMember(fields, "version", fmt.Sprintf("version: %d → %d", old.Version, new.Version))
+	}
+	return fields
+}
+
+// compareApplications ignores the fields, they are compared separately.
+func compareApplications(old laneclient.Application, new laneclient.Application) []string {
+	fields := changedMembers("", old, new, "modifiedDate", "fields")
+	if old.Version != new.Version {
+		fields = replaceMember(fields, "version", fmt.Sprintf("version: %d → %d", old.Version, new.Version))
+	}
+	return fields
+}
+
+func compareFields(old appField, new appField) []string {
+	return changedMembers("", old.field, new.field)
+}
+
+// compareConnectors reports version changes and the actions that were added or removed.
+func compareConnectors(old laneclient.Connector, new laneclient.Connector) []string {
+	fields := make([]string, 0)
+	if old.Meta.Manifest.Version != new.Meta.Manifest.Version {
+		fields = append(fields, fmt.Sprintf("version: %s → %s", old.Meta.Manifest.Version, new.Meta.Manifest.Version))
+	}
+	if added, removed := diffIds(slices.Collect(maps.Keys(old.Meta.Actions)), slices.Collect(maps.Keys(new.Meta.Actions))); len(added)+len(removed) > 0 {
+		fields = append(fields, "actions"+formatTargets(added, removed))
+	}
+	for _, name := range slices.Sorted(maps.Keys(new.Meta.Actions)) {
+		if oldAction, exists := old.Meta.Actions[name]; exists && len(changedMembers("", oldAction, new.Meta.Actions[name])) > 0 {
+			fields = append(fields, "action "+name)
+		}
+	}
+	return fields
+}
+
+func compareSensors(old laneclient.Sensor, new laneclient.Sensor) []string {
+	return append(changedMembers("meta.", old.Meta, new.Meta), changedMembers("sensor.", old.Sensor, new.Sensor)...)
+}
+
+func compareTasks(old laneclient.OrchestrationTask, new laneclient.OrchestrationTask) []string {
+	return changedMembers("", old, new)
+}
+
+// changedMembers returns the names of the top-level JSON members that differ between old and new, skipped members are ignored.
+func changedMembers(prefix string, old any, new any, skip ...string) []string {
+	oldMembers, newMembers := jsonMembers(old), jsonMembers(new)
+	fields := make([]string, 0)
+	for _, name := range slices.Sorted(maps.Keys(mergeKeys(oldMembers, newMembers))) {
+		if slices.Contains(skip, name) {
+			continue
+		}
+		if !bytes.Equal(oldMembers[name], newMembers[name]) {
+			fields = append(fields, prefix+name)
+		}
+	}
+	return fields
+}
+
+// jsonMembers encodes a value and returns its top-level JSON members, members are re-encoded so equal values have equal bytes.
+func jsonMembers(v any) map[string][]byte {
+	members := make(map[string][]byte)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return members
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return members
+	}
+	for name, value := range raw {
+		members[name], _ = json.Marshal(value)
+	}
+	return members
+}
+
+// replaceMember replaces a member name with a more descriptive change.
+func replaceMember(fields []string, name string, change string) []string {
+	if idx := slices.Index(fields, name); idx >= 0 {
+		fields[idx] = change
+	}
+	return fields
+}
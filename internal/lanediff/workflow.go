@@ -0,0 +1,250 @@
+package lanediff
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// ActionRef identifies an action within a workflow.
+type ActionRef struct {
+	Id    string `json:"id"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+}
+
+func (r ActionRef) String() string {
+	if r.Title == "" {
+		return r.Id
+	}
+	return r.Title
+}
+
+// EdgeChange describes how the outgoing edges of an action changed.
+type EdgeChange struct {
+	Action  ActionRef `json:"action"`
+	Edge    string    `json:"edge"` // on-success, on-failure, on-complete, if, else, or entrypoint.
+	Added   []string  `json:"added,omitempty"`
+	Removed []string  `json:"removed,omitempty"`
+}
+
+// ActionChange describes which properties of an action changed.
+type ActionChange struct {
+	Action ActionRef `json:"action"`
+	Fields []string  `json:"fields"` // inputs, transformations, conditions, loop, title, asset, or action.
+}
+
+// WorkflowDiff holds the action-level differences between two versions of a workflow.
+type WorkflowDiff struct {
+	WorkflowId  string         `json:"workflowId"`
+	Title       string         `json:"title"`
+	Enabled     *[2]bool       `json:"enabled,omitempty"` // Old and new enabled state, only set when it changed.
+	Entrypoints *EdgeChange    `json:"entrypoints,omitempty"`
+	Added       []ActionRef    `json:"added,omitempty"`
+	Removed     []ActionRef    `json:"removed,omitempty"`
+	Rewired     []EdgeChange   `json:"rewired,omitempty"`
+	Modified    []ActionChange `json:"modified,omitempty"`
+}
+
+// IsEmpty returns true if both workflow versions are equivalent.
+func (d WorkflowDiff) IsEmpty() bool {
+	return d.Enabled == nil && d.Entrypoints == nil && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Rewired) == 0 && len(d.Modified) == 0
+}
+
+// Summary returns the differences as human-readable lines, prefixed with + (added), - (removed), or ~ (changed).
+func (d WorkflowDiff) Summary() []string {
+	lines := make([]string, 0)
+	if d.Enabled != nil {
+		state := "disabled"
+		if d.Enabled[1] {
+			state = "enabled"
+		}
+		lines = append(lines, "~ workflow "+state)
+	}
+	if d.Entrypoints != nil {
+		lines = append(lines, "~ entrypoints"+formatTargets(d.Entrypoints.Added, d.Entrypoints.Removed))
+	}
+	for _, ref := range d.Added {
+		lines = append(lines, fmt.Sprintf("+ %s (%s)", ref, ref.Type))
+	}
+	for _, ref := range d.Removed {
+		lines = append(lines, fmt.Sprintf("- %s (%s)", ref, ref.Type))
+	}
+	for _, change := range d.Rewired {
+		lines = append(lines, fmt.Sprintf("~ %s %s", change.Action, change.Edge)+formatTargets(change.Added, change.Removed))
+	}
+	for _, change := range d.Modified {
+		lines = append(lines, fmt.Sprintf("~ %s changed %s", change.Action, strings.Join(change.Fields, ", ")))
+	}
+	return lines
+}
+
+// formatTargets formats added and removed edge targets, e.g. ": +a, -b".
+func formatTargets(added []string, removed []string) string {
+	parts := make([]string, 0, len(added)+len(removed))
+	for _, id := range added {
+		parts = append(parts, "+"+id)
+	}
+	for _, id := range removed {
+		parts = append(parts, "-"+id)
+	}
+	return ": " + strings.Join(parts, ", ")
+}
+
+// DiffWorkflows compares two versions of a workflow down to the action level.
+func DiffWorkflows(old laneclient.Workflow, new laneclient.Workflow) WorkflowDiff {
+	diff := WorkflowDiff{
+		WorkflowId: new.Id,
+		Title:      new.Playbook.Title,
+	}
+	if diff.WorkflowId == "" {
+		diff.WorkflowId = old.Id
+		diff.Title = old.Playbook.Title
+	}
+
+	if old.Meta.Enabled != new.Meta.Enabled {
+		diff.Enabled = &[2]bool{old.Meta.Enabled, new.Meta.Enabled}
+	}
+
+	if added, removed := diffIds(old.Playbook.Entrypoints, new.Playbook.Entrypoints); len(added)+len(removed) > 0 {
+		diff.Entrypoints = &EdgeChange{Edge: "entrypoint", Added: added, Removed: removed}
+	}
+
+	oldActions := flattenActions(old.Playbook.Actions)
+	newActions := flattenActions(new.Playbook.Actions)
+
+	for _, actId := range slices.Sorted(maps.Keys(newActions)) {
+		newAction := newActions[actId]
+		ref := ActionRef{Id: actId, Title: newAction.Title, Type: newAction.Type}
+
+		oldAction, exists := oldActions[actId]
+		if !exists {
+			diff.Added = append(diff.Added, ref)
+			continue
+		}
+
+		// Compare the outgoing edges
+		oldEdges, newEdges := actionEdges(oldAction), actionEdges(newAction)
+		for _, edge := range slices.Sorted(maps.Keys(mergeKeys(oldEdges, newEdges))) {
+			if added, removed := diffIds(oldEdges[edge], newEdges[edge]); len(added)+len(removed) > 0 {
+				diff.Rewired = append(diff.Rewired, EdgeChange{Action: ref, Edge: edge, Added: added, Removed: removed})
+			}
+		}
+
+		if fields := changedFields(oldAction, newAction); len(fields) > 0 {
+			diff.Modified = append(diff.Modified, ActionChange{Action: ref, Fields: fields})
+		}
+	}
+
+	for _, actId := range slices.Sorted(maps.Keys(oldActions)) {
+		if _, exists := newActions[actId]; !exists {
+			oldAction := oldActions[actId]
+			diff.Removed = append(diff.Removed, ActionRef{Id: actId, Title: oldAction.Title, Type: oldAction.Type})
+		}
+	}
+
+	return diff
+}
+
+// flattenActions collects all actions of a workflow, including the inner actions of loops and parallels.
+func flattenActions(actions map[string]laneclient.PlaybookAction) map[string]laneclient.PlaybookAction {
+	flat := make(map[string]laneclient.PlaybookAction, len(actions))
+	for actId, action := range actions {
+		flat[actId] = action
+		maps.Copy(flat, flattenActions(action.Actions))
+	}
+	return flat
+}
+
+// actionEdges returns the targets of the outgoing edges of an action, keyed by edge type.
+func actionEdges(action laneclient.PlaybookAction) map[string][]string {
+	edges := make(map[string][]string)
+
+	for edge, actionMaps := range map[string][]map[string]any{
+		"on-success":  action.OnSuccess,
+		"on-failure":  action.OnFailure,
+		"on-complete": action.OnComplete,
+	} {
+		for _, actionMap := range actionMaps {
+			for id := range actionMap {
+				edges[edge] = append(edges[edge], id)
+			}
+		}
+	}
+
+	for _, condition := range action.Conditions {
+		if condition.Action != "" {
+			edges["if"] = append(edges["if"], condition.Action)
+		}
+	}
+	if action.Else != "" {
+		edges["else"] = append(edges["else"], action.Else)
+	}
+	if len(action.Entrypoints) > 0 {
+		edges["entrypoint"] = append(edges["entrypoint"], action.Entrypoints...)
+	}
+
+	return edges
+}
+
+// changedFields returns the names of the action properties that differ.
+func changedFields(old laneclient.PlaybookAction, new laneclient.PlaybookAction) []string {
+	fields := make([]string, 0)
+	if old.Title != new.Title {
+		fields = append(fields, "title")
+	}
+	if old.Action != new.Action {
+		fields = append(fields, "action")
+	}
+	if old.Asset != new.Asset {
+		fields = append(fields, "asset")
+	}
+	if !reflect.DeepEqual(old.Inputs, new.Inputs) {
+		fields = append(fields, "inputs")
+	}
+	if !reflect.DeepEqual(old.Transformations, new.Transformations) {
+		fields = append(fields, "transformations")
+	}
+	if !reflect.DeepEqual(old.Conditions, new.Conditions) {
+		fields = append(fields, "conditions")
+	}
+	if !reflect.DeepEqual(old.Loop, new.Loop) {
+		fields = append(fields, "loop")
+	}
+	return fields
+}
+
+// diffIds returns the ids only present in new (added) and only present in old (removed).
+func diffIds(old []string, new []string) ([]string, []string) {
+	added := make([]string, 0)
+	removed := make([]string, 0)
+	for _, id := range new {
+		if !slices.Contains(old, id) && !slices.Contains(added, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range old {
+		if !slices.Contains(new, id) && !slices.Contains(removed, id) {
+			removed = append(removed, id)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
+
+// mergeKeys returns the union of the keys of both maps.
+func mergeKeys[V any](a map[string]V, b map[string]V) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
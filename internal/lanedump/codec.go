@@ -0,0 +1,333 @@
+package lanedump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the compression applied to a dump file.
+type Compression int
+
+const (
+	CompressionNone Compression = iota
+	CompressionGzip
+	CompressionZstd
+)
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionGzip:
+		return "gzip"
+	case CompressionZstd:
+		return "zstd"
+	}
+	return "none"
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// CompressionForPath chooses the compression from the file extension: .gz for gzip, .zst or .zstd for zstd, anything else is not compressed.
+func CompressionForPath(path string) Compression {
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		return CompressionGzip
+	case strings.HasSuffix(path, ".zst"), strings.HasSuffix(path, ".zstd"):
+		return CompressionZstd
+	}
+	return CompressionNone
+}
+
+// dumpReader reads a dump file, decompressing it on the fly.
+type dumpReader struct {
+	io.Reader
+	compression Compression
+	closers     []io.Closer
+}
+
+// openDump opens a dump file for reading, the compression is detected from the content so misnamed files can be read as well.
+func openDump(path string) (*dumpReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+
+	buffered := bufio.NewReaderSize(file, 1<<16)
+	magic, _ := buffered.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			file.Close() //nolint:errcheck
+			return nil, fmt.Errorf("failed to read gzip header of %s: %w", path, err)
+		}
+		return &dumpReader{Reader: gz, compression: CompressionGzip, closers: []io.Closer{gz, file}}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(buffered)
+		if err != nil {
+			file.Close() //nolint:errcheck
+			return nil, fmt.Errorf("failed to read zstd header of %s: %w", path, err)
+		}
+		zrc := zr.IOReadCloser()
+		return &dumpReader{Reader: zrc, compression: CompressionZstd, closers: []io.Closer{zrc, file}}, nil
+	}
+	return &dumpReader{Reader: buffered, compression: CompressionNone, closers: []io.Closer{file}}, nil
+}
+
+func (r *dumpReader) Close() error {
+	var errs []error
+	for _, closer := range r.closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// dumpWriter writes a dump file, compressing it on the fly.
+type dumpWriter struct {
+	*bufio.Writer
+	compressor io.WriteCloser // Nil for uncompressed dumps.
+	file       *os.File
+}
+
+// createDump creates a dump file, the compression is chosen by the file extension.
+func createDump(path string) (*dumpWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+
+	w := &dumpWriter{file: file}
+	switch CompressionForPath(path) {
+	case CompressionGzip:
+		w.compressor = gzip.NewWriter(file)
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(file)
+		if err != nil {
+			file.Close() //nolint:errcheck
+			return nil, fmt.Errorf("failed to create zstd encoder for %s: %w", path, err)
+		}
+		w.compressor = zw
+	}
+	if w.compressor != nil {
+		w.Writer = bufio.NewWriterSize(w.compressor, 1<<16)
+	} else {
+		w.Writer = bufio.NewWriterSize(file, 1<<16)
+	}
+	return w, nil
+}
+
+// Close flushes the buffered and compressed data and closes the file.
+func (w *dumpWriter) Close() error {
+	errs := []error{w.Flush()}
+	if w.compressor != nil {
+		errs = append(errs, w.compressor.Close())
+	}
+	errs = append(errs, w.file.Close())
+	return errors.Join(errs...)
+}
+
+// stateField is a top-level member of a dump.
+type stateField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// stateFields returns the top-level members of a dump, in the order of the LaneState fields.
+func stateFields() []stateField {
+	t := reflect.TypeFor[LaneState]()
+	fields := make([]stateField, 0, t.NumField())
+	for idx := range t.NumField() {
+		field := t.Field(idx)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, stateField{index: idx, name: name, omitEmpty: strings.Contains(opts, "omitempty")})
+	}
+	return fields
+}
+
+// isStreamedMap returns true if values of the type are encoded and decoded one entry at a time.
+func isStreamedMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// encodeState writes the state as indented JSON, maps are written one entry at a time so the dump is never held in memory as a whole.
+// The output is identical to json.MarshalIndent(laneState, "", "  ").
+func encodeState(w io.Writer, laneState *LaneState) error {
+	state := reflect.ValueOf(laneState).Elem()
+	sep := "{\n"
+	for _, field := range stateFields() {
+		value := state.Field(field.index)
+		if field.omitEmpty && value.IsZero() {
+			continue
+		}
+		key, _ := json.Marshal(field.name)
+		if _, err := fmt.Fprintf(w, "%s  %s: ", sep, key); err != nil {
+			return err
+		}
+		sep = ",\n"
+
+		if isStreamedMap(value.Type()) && !value.IsNil() {
+			if err := encodeMap(w, value, "  "); err != nil {
+				return fmt.Errorf("failed to encode %s: %w", field.name, err)
+			}
+			continue
+		}
+
+		data, err := json.MarshalIndent(value.Interface(), "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", field.name, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	if sep == "{\n" {
+		_, err := io.WriteString(w, "{}")
+		return err
+	}
+	_, err := io.WriteString(w, "\n}")
+	return err
+}
+
+// encodeMap writes a map with string keys as an indented JSON object at the given indentation, sorted by key.
+func encodeMap(w io.Writer, value reflect.Value, indent string) error {
+	if value.Len() == 0 {
+		_, err := io.WriteString(w, "{}")
+		return err
+	}
+
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	slices.Sort(keys)
+
+	sep := "{\n"
+	for _, key := range keys {
+		encodedKey, _ := json.Marshal(key)
+		data, err := json.MarshalIndent(value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key())).Interface(), indent+"  ", "  ")
+		if err != nil {
+			return fmt.Errorf("entry %s: %w", key, err)
+		}
+		if _, err := fmt.Fprintf(w, "%s%s  %s: %s", sep, indent, encodedKey, data); err != nil {
+			return err
+		}
+		sep = ",\n"
+	}
+	_, err := fmt.Fprintf(w, "\n%s}", indent)
+	return err
+}
+
+// decodeState reads the state from a JSON stream, maps are read one entry at a time so the raw JSON is never held in memory as a whole.
+// Members are matched case-insensitively like json.Unmarshal does, unknown members are returned for the migrations.
+func decodeState(r io.Reader, laneState *LaneState) (map[string]json.RawMessage, error) {
+	fieldsByName := make(map[string]stateField)
+	for _, field := range stateFields() {
+		fieldsByName[strings.ToLower(field.name)] = field
+	}
+
+	unknown := make(map[string]json.RawMessage)
+	state := reflect.ValueOf(laneState).Elem()
+	err := walkMembers(json.NewDecoder(r), func(key string, dec *json.Decoder) error {
+		field, known := fieldsByName[strings.ToLower(key)]
+		if !known {
+			var member json.RawMessage
+			if err := dec.Decode(&member); err != nil {
+				return err
+			}
+			unknown[key] = member
+			return nil
+		}
+
+		value := state.Field(field.index)
+		if isStreamedMap(value.Type()) {
+			return decodeMap(dec, value)
+		}
+		return dec.Decode(value.Addr().Interface())
+	})
+	return unknown, err
+}
+
+// walkMembers reads a JSON object from the decoder and calls fn for each member, fn must read the value of the member.
+func walkMembers(dec *json.Decoder, fn func(key string, dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		if err := fn(key, dec); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// decodeMap reads a JSON object into a map with string keys one entry at a time, null leaves the map untouched.
+func decodeMap(dec *json.Decoder, value reflect.Value) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected an object, got %v", token)
+	}
+
+	if value.IsNil() {
+		value.Set(reflect.MakeMap(value.Type()))
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+
+		entry := reflect.New(value.Type().Elem())
+		if err := dec.Decode(entry.Interface()); err != nil {
+			return fmt.Errorf("entry %s: %w", key, err)
+		}
+		value.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), entry.Elem())
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token and returns an error if it isn't the given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if got, ok := token.(json.Delim); !ok || got != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package lanedump
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	state := &LaneState{
+		TimeStamp:      time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Tenant:         laneclient.Tenant{Id: "t1", Name: "Test"},
+		ConnectorsById: map[string]laneclient.Connector{"c1": {Id: "c1"}},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		compression Compression
+		magic       []byte
+	}{
+		{"lanedump.json", CompressionNone, []byte("{")},
+		{"lanedump.json.gz", CompressionGzip, gzipMagic},
+		{"lanedump.json.zst", CompressionZstd, zstdMagic},
+		{"lanedump.json.zstd", CompressionZstd, zstdMagic},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.name)
+			if err := WriteToDisk(state, path); err != nil {
+				t.Fatalf("WriteToDisk failed: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(data, tc.magic) {
+				t.Errorf("file starts with %x, want %x", data[:min(len(data), 4)], tc.magic)
+			}
+
+			loaded, err := LoadFromDisk(path)
+			if err != nil {
+				t.Fatalf("LoadFromDisk failed: %v", err)
+			}
+			if !reflect.DeepEqual(loaded.ConnectorsById, state.ConnectorsById) || loaded.Tenant != state.Tenant {
+				t.Errorf("loaded state differs: %+v", loaded)
+			}
+
+			info, err := ReadInfo(path)
+			if err != nil {
+				t.Fatalf("ReadInfo failed: %v", err)
+			}
+			if info.Compression != tc.compression {
+				t.Errorf("compression = %s, want %s", info.Compression, tc.compression)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package lanedump
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// dirStateFile holds the members of a directory dump that are not split into one file per resource.
+const dirStateFile = "state.json"
+
+// dirMembers maps the LaneState members that are split into one file per resource to their subdirectory in a directory dump.
+var dirMembers = []struct {
+	field string
+	dir   string
+}{
+	{"PlaybooksById", "playbooks"},
+	{"ComponentsById", "components"},
+	{"WorkflowsById", "workflows"},
+	{"ApplicationsById", "applications"},
+	{"ConnectorsById", "connectors"},
+	{"SensorsById", "sensors"},
+}
+
+// IsDumpDir returns true if the path is a directory, directory dumps hold one file per resource.
+func IsDumpDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// resourceFileName returns the file name of a resource: the lowercased id with unsafe characters replaced, followed by a short hash of the raw id.
+// The hash keeps ids that only differ in case or unsafe characters apart on case-insensitive filesystems.
+func resourceFileName(id string) string {
+	safe := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(id))
+	if len(safe) > 64 {
+		safe = safe[:64]
+	}
+	sum := sha256.Sum256([]byte(id))
+	return safe + "-" + hex.EncodeToString(sum[:4]) + ".json"
+}
+
+// resourceId returns the id member of an encoded resource, the file name is not used as the id.
+func resourceId(data []byte) (string, error) {
+	var resource struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return "", err
+	}
+	if resource.Id == "" {
+		return "", errors.New("resource has no id")
+	}
+	return resource.Id, nil
+}
+
+// writeDumpDir writes the state as a directory with one pretty-printed, key-sorted file per resource, so dumps can be versioned and reviewed per resource.
+// Files of resources that no longer exist are removed, other files in the directory are left alone.
+func writeDumpDir(laneState *LaneState, path string) error {
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", path, err)
+	}
+
+	// The split members are left out of the state file
+	rest := *laneState
+	state := reflect.ValueOf(&rest).Elem()
+	for _, member := range dirMembers {
+		resources := state.FieldByName(member.field)
+		if err := writeResourceDir(filepath.Join(path, member.dir), resources); err != nil {
+			return err
+		}
+		resources.SetZero()
+	}
+
+	writer, err := createDump(filepath.Join(path, dirStateFile))
+	if err != nil {
+		return err
+	}
+	if err := encodeState(writer, &rest); err != nil {
+		writer.Close() //nolint:errcheck
+		return fmt.Errorf("failed to write JSON to file %s: %w", dirStateFile, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write JSON to file %s: %w", dirStateFile, err)
+	}
+	return nil
+}
+
+// writeResourceDir writes each entry of a map to its own file, unchanged files are not rewritten.
+// A nil map (resource type not dumped) leaves no directory behind, so it is read back as nil.
+func writeResourceDir(dir string, resources reflect.Value) error {
+	if resources.IsNil() {
+		if err := removeStaleResources(dir, nil); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		os.Remove(dir) //nolint:errcheck // Only removed when empty
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Names are compared case-insensitively, so a dump written on one filesystem reads back the same on any other
+	written := make(map[string]bool, resources.Len())
+	idsByName := make(map[string]string, resources.Len())
+	for key, resource := range resources.Seq2() {
+		name := resourceFileName(key.String())
+		data, err := canonicalJSON(resource.Interface())
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", filepath.Join(dir, name), err)
+		}
+
+		// Resources are read back by their id member, it must match the key they are stored under
+		if id, err := resourceId(data); err != nil || id != key.String() {
+			return fmt.Errorf("resource %s in %s can't be stored in a directory dump, the id member doesn't match", key.String(), dir)
+		}
+		if other, exists := idsByName[strings.ToLower(name)]; exists {
+			return fmt.Errorf("resources %s and %s in %s map to the same file name %s", other, key.String(), dir, name)
+		}
+		idsByName[strings.ToLower(name)] = key.String()
+		written[name] = true
+
+		file := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(file); err == nil && bytes.Equal(existing, data) {
+			continue
+		}
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+
+	return removeStaleResources(dir, written)
+}
+
+// removeStaleResources removes the resource files of a directory that were not written.
+func removeStaleResources(dir string, written map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), ".json") && !written[entry.Name()] {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				return fmt.Errorf("failed to remove stale resource: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// canonicalJSON encodes a value as indented JSON with the object members sorted by name, HTML characters are not escaped to keep scripts readable.
+func canonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decoding into generic values sorts the members when encoding again, numbers are kept as is
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readDumpDir reads a directory dump, unknown members of the state file are returned for the migrations.
+func readDumpDir(path string, laneState *LaneState) (map[string]json.RawMessage, error) {
+	reader, err := openDump(filepath.Join(path, dirStateFile))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close() //nolint:errcheck
+
+	unknown, err := decodeState(reader, laneState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON from %s: %w", dirStateFile, err)
+	}
+
+	state := reflect.ValueOf(laneState).Elem()
+	for _, member := range dirMembers {
+		if err := readResourceDir(filepath.Join(path, member.dir), state.FieldByName(member.field)); err != nil {
+			return nil, err
+		}
+	}
+	return unknown, nil
+}
+
+// readResourceDir reads the files of a resource directory into a map keyed by the id member of each file, a missing directory leaves the map nil.
+func readResourceDir(dir string, resources reflect.Value) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	resources.Set(reflect.MakeMap(resources.Type()))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		id, err := resourceId(data)
+		if err != nil {
+			return fmt.Errorf("failed to read the id from %s: %w", file, err)
+		}
+		key := reflect.ValueOf(id).Convert(resources.Type().Key())
+		if resources.MapIndex(key).IsValid() {
+			return fmt.Errorf("duplicate resource %s in %s", id, dir)
+		}
+
+		resource := reflect.New(resources.Type().Elem())
+		if err := json.Unmarshal(data, resource.Interface()); err != nil {
+			return fmt.Errorf("failed to decode JSON from %s: %w", file, err)
+		}
+		resources.SetMapIndex(key, resource.Elem())
+	}
+	return nil
+}
+
+// countResourceDirs returns the number of resource files of each split member of a directory dump.
+func countResourceDirs(path string) map[string]int {
+	counts := make(map[string]int)
+	for _, member := range dirMembers {
+		entries, err := os.ReadDir(filepath.Join(path, member.dir))
+		if err != nil {
+			continue
+		}
+		count := 0
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), ".json") {
+				count++
+			}
+		}
+		counts[member.field] = count
+	}
+	return counts
+}
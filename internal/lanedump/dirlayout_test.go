@@ -0,0 +1,72 @@
+package lanedump
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+func TestResourceFileName(t *testing.T) {
+	// Ids that only differ in case or in unsafe characters must not share a file on case-insensitive filesystems
+	names := make(map[string]string)
+	for _, id := range []string{"aBc123", "abc123", "ABC123", "a/b", "a_b", "a.b", "../x"} {
+		name := strings.ToLower(resourceFileName(id))
+		if other, exists := names[name]; exists {
+			t.Errorf("ids %q and %q share the file name %s", other, id, name)
+		}
+		names[name] = id
+		if name != resourceFileName(id) || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+			t.Errorf("unsafe file name %q for id %q", resourceFileName(id), id)
+		}
+	}
+}
+
+func TestDirLayoutRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump")
+	state := &LaneState{
+		Tenant: laneclient.Tenant{Id: "t1", Name: "Test"},
+		ConnectorsById: map[string]laneclient.Connector{
+			"aBc": {Id: "aBc"},
+			"abc": {Id: "abc"},
+			"a/b": {Id: "a/b"},
+		},
+	}
+	if err := WriteToDisk(state, path, WithDirLayout()); err != nil {
+		t.Fatalf("WriteToDisk failed: %v", err)
+	}
+
+	loaded, err := LoadFromDisk(path)
+	if err != nil {
+		t.Fatalf("LoadFromDisk failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.ConnectorsById, state.ConnectorsById) {
+		t.Errorf("connectors = %v, want %v", loaded.ConnectorsById, state.ConnectorsById)
+	}
+
+	// Removed resources are removed from the directory and the counts follow
+	delete(state.ConnectorsById, "abc")
+	if err := WriteToDisk(state, path); err != nil {
+		t.Fatalf("WriteToDisk failed: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(path, "connectors"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("%d connector files, want 2", len(entries))
+	}
+	if counts := countResourceDirs(path); counts["ConnectorsById"] != 2 {
+		t.Errorf("counted %d connectors, want 2", counts["ConnectorsById"])
+	}
+}
+
+func TestDirLayoutIdMismatch(t *testing.T) {
+	state := &LaneState{ConnectorsById: map[string]laneclient.Connector{"c1": {Id: "c2"}}}
+	if err := WriteToDisk(state, filepath.Join(t.TempDir(), "dump"), WithDirLayout()); err == nil {
+		t.Fatal("expected an error for a resource stored under another id")
+	}
+}
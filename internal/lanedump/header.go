@@ -0,0 +1,153 @@
+package lanedump
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// CurrentSchemaVersion is the version of the dump format written by this build.
+// Bump it and add a migration to the chain when a change to LaneState or a laneclient model affects how older dumps are read.
+const CurrentSchemaVersion = 2
+
+// DumpHeader describes how and by what a dump was created.
+type DumpHeader struct {
+	SchemaVersion   int                      // Version of the dump format, older dumps are migrated on load. Dumps from before the header have version 0.
+	SwimPeekVersion string                   // Version of SwimPeek that created the dump.
+	TurbineVersion  string                   // Turbine version reported by the tenant, empty if it couldn't be determined.
+	FetchDurations  map[string]time.Duration `json:",omitempty"` // Time spent fetching each resource type from the tenant.
+	MigratedFrom    *int                     `json:",omitempty"` // Schema version the dump was migrated from, set when a migrated dump is written again.
+	Redaction       *RedactionInfo           `json:",omitempty"` // What was redacted, only present for dumps written with redaction.
+}
+
+// fetchTimer records how long fetching each resource type took, it is safe for concurrent use.
+type fetchTimer struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+func newFetchTimer() *fetchTimer {
+	return &fetchTimer{
+		durations: make(map[string]time.Duration),
+	}
+}
+
+// track wraps fn to record its duration under the given resource type.
+func (t *fetchTimer) track(resource string, fn func() error) func() error {
+	return func() error {
+		start := time.Now()
+		err := fn()
+		t.add(resource, time.Since(start))
+		return err
+	}
+}
+
+// add adds to the duration of a resource type.
+func (t *fetchTimer) add(resource string, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.durations[resource] += duration
+}
+
+// DumpInfo summarizes a dump without loading its content.
+type DumpInfo struct {
+	Header      DumpHeader
+	Compression Compression
+	TimeStamp   time.Time
+	Tenant      laneclient.Tenant
+	Profile     string
+	RunsSince   time.Time
+	Refresh     *RefreshInfo
+	FetchErrors map[string]string
+	Counts      map[string]int // Number of entries of each map or list member.
+}
+
+// ReadInfo reads the header and summary of a dump, the resources are counted but not decoded.
+func ReadInfo(path string) (*DumpInfo, error) {
+	if IsDumpDir(path) {
+		info, err := readInfo(filepath.Join(path, dirStateFile))
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(info.Counts, countResourceDirs(path))
+		return info, nil
+	}
+	return readInfo(path)
+}
+
+// readInfo reads the header and summary of a dump file.
+func readInfo(path string) (*DumpInfo, error) {
+	reader, err := openDump(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close() //nolint:errcheck
+
+	info := &DumpInfo{
+		Compression: reader.compression,
+		Counts:      make(map[string]int),
+	}
+	summary := map[string]any{
+		"header":      &info.Header,
+		"timestamp":   &info.TimeStamp,
+		"tenant":      &info.Tenant,
+		"profile":     &info.Profile,
+		"runssince":   &info.RunsSince,
+		"refresh":     &info.Refresh,
+		"fetcherrors": &info.FetchErrors,
+	}
+
+	err = walkMembers(json.NewDecoder(reader), func(key string, dec *json.Decoder) error {
+		if target, exists := summary[strings.ToLower(key)]; exists {
+			return dec.Decode(target)
+		}
+		count, err := countEntries(dec)
+		if err != nil {
+			return err
+		}
+		if count >= 0 {
+			info.Counts[key] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return info, nil
+}
+
+// countEntries reads a JSON value and returns the number of members or elements, -1 is returned for other values.
+func countEntries(dec *json.Decoder) (int, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return 0, err
+	}
+	delim, isDelim := token.(json.Delim)
+	if !isDelim || (delim != '{' && delim != '[') {
+		return -1, nil
+	}
+
+	count := 0
+	for dec.More() {
+		if delim == '{' {
+			if _, err := dec.Token(); err != nil {
+				return 0, err
+			}
+		}
+		var skipped json.RawMessage
+		if err := dec.Decode(&skipped); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if _, err := dec.Token(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
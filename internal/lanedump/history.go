@@ -0,0 +1,67 @@
+package lanedump
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// SolutionRevision is a playbook or component and its workflows as they were at a revision.
+type SolutionRevision struct {
+	laneclient.SolutionRevision
+	Solution  laneclient.OrchestrationSolution
+	Workflows map[string]laneclient.Workflow
+}
+
+// HasHistory returns true if the state includes revision history for the given playbook or component.
+func (s *LaneState) HasHistory(solutionId string) bool {
+	return len(s.HistoryBySolutionId[solutionId]) > 0
+}
+
+// LoadSolutionHistory loads the latest revisions of a playbook or component including the workflows at each revision, newest first.
+// A limit of zero loads all revisions.
+func LoadSolutionHistory(ctx context.Context, laneClient *laneclient.TenantClient, kind laneclient.SolutionKind, solutionId string, limit int) ([]SolutionRevision, error) {
+	history, err := laneClient.GetSolutionHistory(ctx, kind, solutionId)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(history, func(a, b laneclient.SolutionRevision) int {
+		return b.Version - a.Version
+	})
+	if limit > 0 && len(history) > limit {
+		history = history[:limit]
+	}
+
+	revisions := make([]SolutionRevision, 0, len(history))
+	for _, rev := range history {
+		solution, err := laneClient.GetSolutionRevision(ctx, kind, solutionId, rev.Version)
+		if err != nil {
+			return revisions, err
+		}
+
+		workflowIds := slices.Clone(solution.PlaybookIds)
+		if solution.PlaybookId != "" {
+			workflowIds = append(workflowIds, solution.PlaybookId)
+		}
+
+		workflows := make(map[string]laneclient.Workflow, len(workflowIds))
+		for _, workflowId := range workflowIds {
+			workflow, err := laneClient.GetWorkflowRevision(ctx, workflowId, rev.Version)
+			if err != nil {
+				return revisions, fmt.Errorf("failed to load revision %d: %w", rev.Version, err)
+			}
+			workflows[workflowId] = workflow
+		}
+
+		revisions = append(revisions, SolutionRevision{
+			SolutionRevision: rev,
+			Solution:         solution,
+			Workflows:        workflows,
+		})
+		logger.Info("Loaded revision", "solution", solution.Name, "version", rev.Version, "workflows", len(workflows))
+	}
+
+	return revisions, nil
+}
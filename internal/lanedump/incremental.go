@@ -0,0 +1,162 @@
+package lanedump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// refreshConcurrency is the number of workflows or applications fetched in parallel for an incremental dump.
+const refreshConcurrency = 8
+
+// RefreshInfo records what an incremental dump fetched from the tenant, all other workflows and applications were carried over from the base dump.
+type RefreshInfo struct {
+	BaseTimeStamp           time.Time // Time stamp of the base dump.
+	ChangedSolutionIds      []string  // Playbooks and components that are new or have a different version or modification date.
+	RemovedSolutionIds      []string  // Playbooks and components that are no longer in the tenant.
+	RefreshedWorkflowIds    []string  // Workflows that were fetched from the tenant.
+	RefreshedApplicationIds []string  // Applications that are new or have a different version or modification date.
+	RemovedApplicationIds   []string  // Applications that are no longer in the tenant.
+}
+
+// IsIncremental returns true if the state was created from a base dump.
+func (s *LaneState) IsIncremental() bool {
+	return s.Refresh != nil
+}
+
+// unchanged returns true if the version and modification date match.
+func unchanged(version int, modified time.Time, baseVersion int, baseModified time.Time) bool {
+	return version == baseVersion && modified.Equal(baseModified)
+}
+
+// loadChangedApplications fetches the applications that changed since the base dump, unchanged applications are carried over.
+func loadChangedApplications(ctx context.Context, laneClient *laneclient.TenantClient, laneState *LaneState, base *LaneState) error {
+	summaries, err := laneClient.GetApplicationSummaries(ctx)
+	if err != nil {
+		return err
+	}
+
+	laneState.ApplicationsById = make(map[string]laneclient.Application, len(summaries))
+	changedIds := make([]string, 0)
+	for _, summary := range summaries {
+		baseApp, exists := base.ApplicationsById[summary.Id]
+		if exists && unchanged(summary.Version, summary.ModifiedDate, baseApp.Version, baseApp.ModifiedDate) {
+			laneState.ApplicationsById[summary.Id] = baseApp
+			continue
+		}
+		changedIds = append(changedIds, summary.Id)
+	}
+
+	var mu sync.Mutex
+	eg, _ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(refreshConcurrency)
+	for _, appId := range changedIds {
+		eg.Go(func() error {
+			app, err := laneClient.GetApplication(_ctx, appId)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			laneState.ApplicationsById[appId] = app
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	slices.Sort(changedIds)
+	laneState.Refresh.RefreshedApplicationIds = changedIds
+	laneState.Refresh.RemovedApplicationIds = removedIds(base.ApplicationsById, laneState.ApplicationsById)
+	return nil
+}
+
+// loadChangedWorkflows fetches the workflows of the playbooks and components that changed since the base dump, the workflows of unchanged solutions are carried over.
+// The playbooks and components of the state must be loaded before calling this.
+func loadChangedWorkflows(ctx context.Context, laneClient *laneclient.TenantClient, laneState *LaneState, base *LaneState) error {
+	laneState.WorkflowsById = make(map[string]laneclient.Workflow)
+	fetchIds := make([]string, 0)
+	changedIds := make([]string, 0)
+
+	checkFn := func(solutions map[string]laneclient.OrchestrationSolution, baseSolutions map[string]laneclient.OrchestrationSolution) {
+		for id, solution := range solutions {
+			baseSolution, exists := baseSolutions[id]
+			if !exists || !unchanged(solution.Version, solution.ModifiedDate, baseSolution.Version, baseSolution.ModifiedDate) {
+				changedIds = append(changedIds, id)
+				fetchIds = append(fetchIds, solution.PlaybookIds...)
+				continue
+			}
+			for _, workflowId := range solution.PlaybookIds {
+				if workflow, exists := base.WorkflowsById[workflowId]; exists {
+					laneState.WorkflowsById[workflowId] = workflow
+				} else {
+					fetchIds = append(fetchIds, workflowId)
+				}
+			}
+		}
+	}
+	checkFn(laneState.PlaybooksById, base.PlaybooksById)
+	checkFn(laneState.ComponentsById, base.ComponentsById)
+	slices.Sort(fetchIds)
+	fetchIds = slices.Compact(fetchIds)
+
+	var mu sync.Mutex
+	eg, _ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(refreshConcurrency)
+	for _, workflowId := range fetchIds {
+		eg.Go(func() error {
+			workflow, err := laneClient.GetWorkflow(_ctx, workflowId)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			laneState.WorkflowsById[workflowId] = workflow
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	slices.Sort(changedIds)
+	laneState.Refresh.ChangedSolutionIds = changedIds
+	laneState.Refresh.RefreshedWorkflowIds = fetchIds
+	laneState.Refresh.RemovedSolutionIds = slices.Sorted(slices.Values(append(
+		removedIds(base.PlaybooksById, laneState.PlaybooksById),
+		removedIds(base.ComponentsById, laneState.ComponentsById)...,
+	)))
+	return nil
+}
+
+// removedIds returns the sorted keys of base that are not in current.
+func removedIds[T any](base map[string]T, current map[string]T) []string {
+	removed := make([]string, 0)
+	for _, id := range slices.Sorted(maps.Keys(base)) {
+		if _, exists := current[id]; !exists {
+			removed = append(removed, id)
+		}
+	}
+	return removed
+}
+
+// checkBase returns an error if the base dump can't be used for an incremental dump of the tenant.
+func checkBase(base *LaneState, tenant laneclient.Tenant) error {
+	if base.Tenant.Id != tenant.Id {
+		return fmt.Errorf("base dump is for tenant %s (%s), not %s (%s)", base.Tenant.Name, base.Tenant.Id, tenant.Name, tenant.Id)
+	}
+	// Unchanged resources are copied from the base, placeholders would end up in the new dump
+	if base.Header.Redaction != nil {
+		return errors.New("base dump is redacted, use an unredacted dump as the base")
+	}
+	return nil
+}
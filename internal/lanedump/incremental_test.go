@@ -0,0 +1,27 @@
+package lanedump
+
+import (
+	"testing"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+func TestCheckBase(t *testing.T) {
+	tenant := laneclient.Tenant{Id: "t1", Name: "Test"}
+
+	for _, tc := range []struct {
+		name  string
+		base  *LaneState
+		valid bool
+	}{
+		{"same tenant", &LaneState{Tenant: tenant}, true},
+		{"other tenant", &LaneState{Tenant: laneclient.Tenant{Id: "t2"}}, false},
+		{"redacted", &LaneState{Tenant: tenant, Header: DumpHeader{Redaction: &RedactionInfo{}}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := checkBase(tc.base, tenant); (err == nil) != tc.valid {
+				t.Errorf("checkBase() = %v, want valid = %v", err, tc.valid)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package lanedump
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/just-oblivious/swimpeek/internal/config"
@@ -18,57 +20,120 @@ import (
 var logger *log.Logger = config.GetLogger("lanedump")
 
 // LoadFromTenant loads the orchestration state from a tenant.
-func LoadFromTenant(ctx context.Context, laneClient *laneclient.TenantClient) (*LaneState, error) {
+func LoadFromTenant(ctx context.Context, laneClient *laneclient.TenantClient, opts LoadOptions) (*LaneState, error) {
 	laneState := LaneState{
+		Header:    DumpHeader{SchemaVersion: CurrentSchemaVersion},
 		TimeStamp: time.Now(),
 		Tenant:    laneClient.Tenant,
 	}
+	timer := newFetchTimer()
+	var errMu sync.Mutex
+
+	recordFetchError := func(resource string, err error) {
+		errMu.Lock()
+		defer errMu.Unlock()
+		if laneState.FetchErrors == nil {
+			laneState.FetchErrors = make(map[string]string)
+		}
+		laneState.FetchErrors[resource] = err.Error()
+	}
+
+	// fetch wraps the loading of a resource type, best-effort dumps record the error and carry on without the resource type
+	fetch := func(resource string, fn func() error) func() error {
+		return timer.track(resource, func() error {
+			err := fn()
+			if err == nil || !opts.BestEffort {
+				return err
+			}
+			logger.Warn("Failed to fetch resources, continuing without them", "resource", resource, "error", err)
+			recordFetchError(resource, err)
+			return nil
+		})
+	}
+
+	// fetchOptional is fetch for resource types that are not available on every tenant or to every user,
+	// when access is denied or the endpoint doesn't exist the resource type is recorded as unavailable and the dump carries on
+	fetchOptional := func(resource string, fn func() error) func() error {
+		return fetch(resource, func() error {
+			err := fn()
+			if laneclient.IsForbidden(err) || laneclient.IsNotFound(err) {
+				logger.Warn("Resources are unavailable, continuing without them", "resource", resource, "error", err)
+				recordFetchError(resource, fmt.Errorf("unavailable: %w", err))
+				return nil
+			}
+			return err
+		})
+	}
+
+	// Incremental dumps only fetch the workflows and applications that changed since the base dump
+	if opts.Base != nil {
+		if err := checkBase(opts.Base, laneClient.Tenant); err != nil {
+			return &laneState, err
+		}
+		laneState.Refresh = &RefreshInfo{BaseTimeStamp: opts.Base.TimeStamp}
+	}
 
 	eg, _ctx := errgroup.WithContext(ctx)
 
 	logger.Info("Enumerating tenant...")
 
-	// Playbooks
-	eg.Go(func() error {
-		playbooks, err := laneClient.GetPlaybooks(_ctx)
+	// Turbine version, the dump is still usable without it
+	eg.Go(fetch("version", func() error {
+		version, err := laneClient.GetProductVersion(_ctx)
 		if err != nil {
-			return fmt.Errorf("failed to get playbooks: %w", err)
+			logger.Warn("Failed to determine the Turbine version", "error", err)
+			return nil
 		}
-		laneState.PlaybooksById = make(map[string]laneclient.OrchestrationSolution, len(playbooks))
-		for _, solution := range playbooks {
+		laneState.Header.TurbineVersion = version
+		return nil
+	}))
+
+	// Playbooks
+	eg.Go(fetch("playbooks", func() error {
+		laneState.PlaybooksById = make(map[string]laneclient.OrchestrationSolution)
+		for solution, err := range laneClient.StreamPlaybooks(_ctx) {
+			if err != nil {
+				return fmt.Errorf("failed to get playbooks: %w", err)
+			}
 			laneState.PlaybooksById[solution.Id] = solution
 		}
 		return nil
-	})
+	}))
 
 	// Components
-	eg.Go(func() error {
-		components, err := laneClient.GetComponents(_ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get components: %w", err)
-		}
-		laneState.ComponentsById = make(map[string]laneclient.OrchestrationSolution, len(components))
-		for _, component := range components {
+	eg.Go(fetch("components", func() error {
+		laneState.ComponentsById = make(map[string]laneclient.OrchestrationSolution)
+		for component, err := range laneClient.StreamComponents(_ctx) {
+			if err != nil {
+				return fmt.Errorf("failed to get components: %w", err)
+			}
 			laneState.ComponentsById[component.Id] = component
 		}
 		return nil
-	})
+	}))
 
-	// Playbook workflows
-	eg.Go(func() error {
-		workflows, err := laneClient.GetPlaybookWorkflows(_ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get workflows: %w", err)
-		}
-		laneState.WorkflowsById = make(map[string]laneclient.Workflow, len(workflows))
-		for _, workflow := range workflows {
-			laneState.WorkflowsById[workflow.Id] = workflow
-		}
-		return nil
-	})
+	// Playbook workflows, incremental dumps fetch them once the playbooks and components are known
+	if opts.Base == nil {
+		eg.Go(fetch("workflows", func() error {
+			laneState.WorkflowsById = make(map[string]laneclient.Workflow)
+			for workflow, err := range laneClient.StreamPlaybookWorkflows(_ctx) {
+				if err != nil {
+					return fmt.Errorf("failed to get workflows: %w", err)
+				}
+				laneState.WorkflowsById[workflow.Id] = workflow
+			}
+			return nil
+		}))
+	}
 
 	// Applications
-	eg.Go(func() error {
+	eg.Go(fetch("applications", func() error {
+		if opts.Base != nil {
+			if err := loadChangedApplications(_ctx, laneClient, &laneState, opts.Base); err != nil {
+				return fmt.Errorf("failed to get changed applications: %w", err)
+			}
+			return nil
+		}
 		applications, err := laneClient.GetApplications(_ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get applications: %w", err)
@@ -78,94 +143,338 @@ func LoadFromTenant(ctx context.Context, laneClient *laneclient.TenantClient) (*
 			laneState.ApplicationsById[app.Id] = app
 		}
 		return nil
-	})
+	}))
 
 	// Connectors
-	eg.Go(func() error {
-		connectors, err := laneClient.GetConnectors(_ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get connectors: %w", err)
-		}
-		laneState.ConnectorsById = make(map[string]laneclient.Connector, len(connectors))
-		for _, connector := range connectors {
+	eg.Go(fetch("connectors", func() error {
+		laneState.ConnectorsById = make(map[string]laneclient.Connector)
+		for connector, err := range laneClient.StreamConnectors(_ctx) {
+			if err != nil {
+				return fmt.Errorf("failed to get connectors: %w", err)
+			}
 			laneState.ConnectorsById[connector.Id] = connector
 		}
 		return nil
-	})
+	}))
 
 	// Sensors
-	eg.Go(func() error {
-		sensors, err := laneClient.GetSensors(_ctx)
+	eg.Go(fetch("sensors", func() error {
+		laneState.SensorsById = make(map[string]laneclient.Sensor)
+		for sensor, err := range laneClient.StreamSensors(_ctx) {
+			if err != nil {
+				return fmt.Errorf("failed to get sensors: %w", err)
+			}
+			laneState.SensorsById[sensor.Id] = sensor
+		}
+		return nil
+	}))
+
+	// Assets
+	eg.Go(fetch("assets", func() error {
+		laneState.AssetsById = make(map[string]laneclient.Asset)
+		for asset, err := range laneClient.StreamAssets(_ctx) {
+			if err != nil {
+				return fmt.Errorf("failed to get assets: %w", err)
+			}
+			laneState.AssetsById[asset.Id] = asset
+		}
+		return nil
+	}))
+
+	// Workspaces
+	eg.Go(fetchOptional("workspaces", func() error {
+		workspaces, err := laneClient.GetWorkspaces(_ctx)
 		if err != nil {
-			return fmt.Errorf("failed to get sensors: %w", err)
+			return fmt.Errorf("failed to get workspaces: %w", err)
 		}
-		laneState.SensorsById = make(map[string]laneclient.Sensor, len(sensors))
-		for _, sensor := range sensors {
-			laneState.SensorsById[sensor.Id] = sensor
+		laneState.WorkspacesById = make(map[string]laneclient.Workspace, len(workspaces))
+		for _, workspace := range workspaces {
+			laneState.WorkspacesById[workspace.Id] = workspace
+		}
+		return nil
+	}))
+
+	// Dashboards
+	eg.Go(fetchOptional("dashboards", func() error {
+		dashboards, err := laneClient.GetDashboards(_ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get dashboards: %w", err)
+		}
+		laneState.DashboardsById = make(map[string]laneclient.Dashboard, len(dashboards))
+		for _, dashboard := range dashboards {
+			laneState.DashboardsById[dashboard.Id] = dashboard
+		}
+		return nil
+	}))
+
+	// Reports
+	eg.Go(fetchOptional("reports", func() error {
+		reports, err := laneClient.GetReports(_ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get reports: %w", err)
+		}
+		laneState.ReportsById = make(map[string]laneclient.Report, len(reports))
+		for _, report := range reports {
+			laneState.ReportsById[report.Id] = report
 		}
 		return nil
-	})
+	}))
 
 	// Orchestration tasks
-	eg.Go(func() error {
+	eg.Go(fetch("orchestrationTasks", func() error {
 		otasks, err := laneClient.GetOrchestrationTasks(_ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get orchestration tasks: %w", err)
 		}
 		laneState.OrchestrationTasks = otasks
 		return nil
-	})
+	}))
+
+	// Legacy integration tasks
+	eg.Go(fetchOptional("legacyTasks", func() error {
+		tasks, err := laneClient.GetLegacyTasks(_ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get legacy tasks: %w", err)
+		}
+		laneState.LegacyTasksById = make(map[string]laneclient.LegacyTask, len(tasks))
+		for _, task := range tasks {
+			laneState.LegacyTasksById[task.Id] = task
+		}
+		return nil
+	}))
+
+	// Legacy triggers
+	eg.Go(fetchOptional("legacyTriggers", func() error {
+		triggers, err := laneClient.GetLegacyTriggers(_ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get legacy triggers: %w", err)
+		}
+		laneState.LegacyTriggersById = make(map[string]laneclient.LegacyTrigger, len(triggers))
+		for _, trigger := range triggers {
+			laneState.LegacyTriggersById[trigger.Id] = trigger
+		}
+		return nil
+	}))
+
+	// Users, groups, and roles (account-level)
+	if opts.WithIdentities {
+		account := laneClient.Account()
+		eg.Go(fetch("users", func() error {
+			users, err := account.GetUsers(_ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get users: %w", err)
+			}
+			laneState.UsersById = make(map[string]laneclient.User, len(users))
+			for _, user := range users {
+				laneState.UsersById[user.Id] = user
+			}
+			return nil
+		}))
+
+		eg.Go(fetch("groups", func() error {
+			groups, err := account.GetGroups(_ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get groups: %w", err)
+			}
+			laneState.GroupsById = make(map[string]laneclient.Group, len(groups))
+			for _, group := range groups {
+				laneState.GroupsById[group.Id] = group
+			}
+			return nil
+		}))
+
+		eg.Go(fetch("roles", func() error {
+			roles, err := account.GetRoles(_ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get roles: %w", err)
+			}
+			laneState.RolesById = make(map[string]laneclient.Role, len(roles))
+			for _, role := range roles {
+				laneState.RolesById[role.Id] = role
+			}
+			return nil
+		}))
+	}
+
+	// Run history
+	if opts.RunsSince > 0 {
+		laneState.RunsSince = laneState.TimeStamp.Add(-opts.RunsSince)
+		eg.Go(fetch("runs", func() error {
+			laneState.RunMetricsByWorkflowId = make(map[string]WorkflowRunMetrics)
+			for run, err := range laneClient.StreamPlaybookRuns(_ctx, laneState.RunsSince) {
+				if err != nil {
+					return fmt.Errorf("failed to get run history: %w", err)
+				}
+				metrics := laneState.RunMetricsByWorkflowId[run.PlaybookId]
+				metrics.addRun(run)
+				laneState.RunMetricsByWorkflowId[run.PlaybookId] = metrics
+			}
+			return nil
+		}))
+	}
 
 	if err := eg.Wait(); err != nil {
 		return &laneState, err
 	}
 
+	if opts.Base != nil {
+		err := fetch("workflows", func() error {
+			return loadChangedWorkflows(ctx, laneClient, &laneState, opts.Base)
+		})()
+		if err != nil {
+			return &laneState, fmt.Errorf("failed to get changed workflows: %w", err)
+		}
+		// The workflows to refresh are derived from the solutions, missing solutions mean missing workflows
+		for _, resource := range []string{"playbooks", "components"} {
+			if _, failed := laneState.FetchErrors[resource]; failed {
+				if _, recorded := laneState.FetchErrors["workflows"]; !recorded {
+					laneState.FetchErrors["workflows"] = fmt.Sprintf("incomplete, %s could not be fetched", resource)
+				}
+			}
+		}
+	}
+	laneState.Header.FetchDurations = timer.durations
+
 	logger.Info("Done!", "playbooks", len(laneState.PlaybooksById),
 		"components", len(laneState.ComponentsById),
 		"workflows", len(laneState.WorkflowsById),
 		"applications", len(laneState.ApplicationsById),
 		"connectors", len(laneState.ConnectorsById),
 		"sensors", len(laneState.SensorsById),
-		"orchestrationTasks", len(laneState.OrchestrationTasks))
+		"assets", len(laneState.AssetsById),
+		"workspaces", len(laneState.WorkspacesById),
+		"dashboards", len(laneState.DashboardsById),
+		"reports", len(laneState.ReportsById),
+		"orchestrationTasks", len(laneState.OrchestrationTasks),
+		"legacyTasks", len(laneState.LegacyTasksById),
+		"legacyTriggers", len(laneState.LegacyTriggersById))
+
+	if len(laneState.FetchErrors) > 0 {
+		logger.Warn("The dump is incomplete, these resources are missing or incomplete", "resources", strings.Join(laneState.MissingResources(), ", "))
+	}
+
+	if laneState.Refresh != nil {
+		logger.Info("Incremental dump", "base", laneState.Refresh.BaseTimeStamp.Format(time.DateTime),
+			"changedSolutions", len(laneState.Refresh.ChangedSolutionIds),
+			"removedSolutions", len(laneState.Refresh.RemovedSolutionIds),
+			"refreshedWorkflows", len(laneState.Refresh.RefreshedWorkflowIds),
+			"refreshedApplications", len(laneState.Refresh.RefreshedApplicationIds),
+			"removedApplications", len(laneState.Refresh.RemovedApplicationIds))
+	}
+
+	if opts.WithIdentities {
+		logger.Info("Identities", "users", len(laneState.UsersById), "groups", len(laneState.GroupsById), "roles", len(laneState.RolesById))
+	}
+
+	if opts.RunsSince > 0 {
+		runs := 0
+		for _, metrics := range laneState.RunMetricsByWorkflowId {
+			runs += metrics.Runs
+		}
+		logger.Info("Run history", "since", laneState.RunsSince.Format(time.DateTime), "runs", runs, "workflows", len(laneState.RunMetricsByWorkflowId))
+	}
+
+	// Report endpoints that needed retries
+	retryCounts := laneClient.RetryCounts()
+	for _, endpoint := range slices.Sorted(maps.Keys(retryCounts)) {
+		logger.Warn("Endpoint needed retries", "endpoint", endpoint, "retries", retryCounts[endpoint])
+	}
 
 	return &laneState, nil
 }
 
-// LoadFromDisk loads an orchestration state from a JSON file on disk.
+// LoadFromDisk loads an orchestration state from a JSON file on disk, gzip and zstd compressed files are decompressed on the fly.
+// The path can also be a directory dump with one file per resource. Dumps with an older schema version are migrated to the current version.
 func LoadFromDisk(path string) (*LaneState, error) {
 	laneState := LaneState{}
 
-	data, err := os.ReadFile(path)
+	if IsDumpDir(path) {
+		unknown, err := readDumpDir(path, &laneState)
+		if err != nil {
+			return &laneState, fmt.Errorf("failed to load %s: %w", path, err)
+		}
+		if err := migrate(&laneState, unknown); err != nil {
+			return &laneState, fmt.Errorf("failed to load %s: %w", path, err)
+		}
+		return &laneState, nil
+	}
+
+	reader, err := openDump(path)
 	if err != nil {
-		return &laneState, fmt.Errorf("failed to read file %s: %w", path, err)
+		return &laneState, err
 	}
+	defer reader.Close() //nolint:errcheck
 
-	if err := json.Unmarshal(data, &laneState); err != nil {
-		return &laneState, fmt.Errorf("failed to unmarshal JSON from %s: %w", path, err)
+	unknown, err := decodeState(reader, &laneState)
+	if err != nil {
+		return &laneState, fmt.Errorf("failed to decode JSON from %s: %w", path, err)
+	}
+
+	if err := migrate(&laneState, unknown); err != nil {
+		return &laneState, fmt.Errorf("failed to load %s: %w", path, err)
 	}
 
 	return &laneState, nil
 }
 
-// WriteToDisk writes an orchestration state to a JSON file on disk.
-func WriteToDisk(laneState *LaneState, path string) error {
-	json, err := json.MarshalIndent(laneState, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal lane state to JSON: %w", err)
+// WriteOptions controls how a state is written to disk, use the With... options to set them.
+type WriteOptions struct {
+	redact *RedactOptions
+	dir    bool
+}
+
+// WithRedaction replaces secrets with placeholders in the written dump, the state in memory is not modified.
+func WithRedaction(opts RedactOptions) func(*WriteOptions) {
+	return func(wo *WriteOptions) {
+		wo.redact = &opts
 	}
+}
 
-	file, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("failed to create file %s: %w", path, err)
+// WithDirLayout writes the dump as a directory with one file per playbook, component, workflow, application, connector, and sensor.
+func WithDirLayout() func(*WriteOptions) {
+	return func(wo *WriteOptions) {
+		wo.dir = true
+	}
+}
+
+// WriteToDisk writes an orchestration state to a JSON file on disk, the file is gzip compressed when the path ends with .gz and zstd compressed when it ends with .zst or .zstd.
+// Existing directory dumps are updated in place, use WithDirLayout to create a new one.
+func WriteToDisk(laneState *LaneState, path string, options ...func(*WriteOptions)) error {
+	wo := WriteOptions{}
+	for _, option := range options {
+		option(&wo)
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			panic(err)
+
+	// States in memory always have the current schema, loaded dumps are migrated and new states start out current
+	laneState.Header.SchemaVersion = CurrentSchemaVersion
+
+	if wo.redact != nil {
+		redacted, err := redactState(laneState, *wo.redact)
+		if err != nil {
+			return err
 		}
-	}()
+		logger.Info("Secrets redacted", "values", len(redacted.Header.Redaction.Redactions))
+		laneState = redacted
+	}
 
-	_, err = file.Write(json)
+	if wo.dir || IsDumpDir(path) {
+		if err := writeDumpDir(laneState, path); err != nil {
+			return fmt.Errorf("failed to write directory dump %s: %w", path, err)
+		}
+		return nil
+	}
+
+	writer, err := createDump(path)
 	if err != nil {
+		return err
+	}
+
+	if err := encodeState(writer, laneState); err != nil {
+		writer.Close() //nolint:errcheck
+		return fmt.Errorf("failed to write JSON to file %s: %w", path, err)
+	}
+
+	if err := writer.Close(); err != nil {
 		return fmt.Errorf("failed to write JSON to file %s: %w", path, err)
 	}
 	return nil
@@ -0,0 +1,77 @@
+package lanedump
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// migration upgrades a dump from schema version from to from+1.
+// Top-level members that LaneState no longer has are passed in unknown, so renamed or restructured members can be carried over.
+type migration struct {
+	from        int
+	description string
+	apply       func(laneState *LaneState, unknown map[string]json.RawMessage) error
+}
+
+// migrations is the chain of upgrades applied to older dumps on load, ordered by schema version.
+var migrations = []migration{
+	{
+		from:        0,
+		description: "add the dump header to dumps from before schema versioning",
+		apply: func(_ *LaneState, _ map[string]json.RawMessage) error {
+			// The tool and Turbine versions of these dumps are unknown, the content is compatible
+			return nil
+		},
+	},
+	{
+		from:        1,
+		description: "add the number of completed runs to the run metrics",
+		apply: func(laneState *LaneState, _ map[string]json.RawMessage) error {
+			// Older dumps don't tell which runs completed, runs with an average duration are assumed to have completed
+			estimate := func(m *RunMetrics) {
+				if m.AverageDuration > 0 {
+					m.Completed = m.Runs
+				}
+			}
+			for wfId, metrics := range laneState.RunMetricsByWorkflowId {
+				estimate(&metrics.RunMetrics)
+				for actId, actMetrics := range metrics.ActionsById {
+					estimate(&actMetrics)
+					metrics.ActionsById[actId] = actMetrics
+				}
+				laneState.RunMetricsByWorkflowId[wfId] = metrics
+			}
+			return nil
+		},
+	},
+}
+
+// migrate upgrades the state to the current schema version, states from a newer version of SwimPeek are rejected.
+func migrate(laneState *LaneState, unknown map[string]json.RawMessage) error {
+	from := laneState.Header.SchemaVersion
+	if from > CurrentSchemaVersion {
+		return fmt.Errorf("dump has schema version %d, this version of SwimPeek reads up to version %d; please upgrade SwimPeek", from, CurrentSchemaVersion)
+	}
+	if from < 0 {
+		return fmt.Errorf("dump has invalid schema version %d", from)
+	}
+
+	for _, m := range migrations[from:] {
+		if m.from != laneState.Header.SchemaVersion {
+			return fmt.Errorf("migration chain is broken at schema version %d", laneState.Header.SchemaVersion)
+		}
+		logger.Debug("Migrating dump", "from", m.from, "to", m.from+1, "migration", m.description)
+		if err := m.apply(laneState, unknown); err != nil {
+			return fmt.Errorf("failed to migrate dump from schema version %d: %w", m.from, err)
+		}
+		laneState.Header.SchemaVersion = m.from + 1
+	}
+
+	if from < CurrentSchemaVersion {
+		if laneState.Header.MigratedFrom == nil {
+			laneState.Header.MigratedFrom = &from
+		}
+		logger.Info("Dump migrated to the current schema", "from", from, "to", CurrentSchemaVersion)
+	}
+	return nil
+}
@@ -0,0 +1,391 @@
+package lanedump
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// DefaultRedactKeyPatterns match the names of members that hold secrets, e.g. in action inputs and HTTP headers.
+var DefaultRedactKeyPatterns = []string{
+	`(?i)^(.*[_-])?(api[_-]?key|apikey|access[_-]?key|secret|client[_-]?secret|passw(or)?d|passwd|pwd|private[_-]?key)$`,
+	`(?i)^(.*[_-])?(token|access[_-]?token|refresh[_-]?token|auth[_-]?token|bearer|authorization|x[_-]api[_-]key|cookie|session[_-]?id|credentials?)$`,
+}
+
+// Defaults for the entropy heuristic.
+const (
+	DefaultRedactMinEntropy     = 4.0 // Shannon entropy in bits per character.
+	DefaultRedactMinTokenLength = 24
+)
+
+var (
+	// secretTokenRegex matches candidate tokens for the entropy heuristic. Slashes and dots separate tokens,
+	// so URLs, paths, and host names are judged per segment instead of as one long random-looking string.
+	secretTokenRegex = regexp.MustCompile(`[A-Za-z0-9+_=\-]+`)
+
+	// inlineSecretRegex matches assignments of secrets in source code and header strings, e.g. api_key = "..." or Authorization: Bearer ...
+	inlineSecretRegex = regexp.MustCompile(`(?i)\b(?:api[_-]?key|apikey|secret|client[_-]?secret|passw(?:or)?d|pwd|token|authorization)\b["']?\s*[:=]\s*(?:bearer\s+|basic\s+|token\s+)?["']?([^\s"',;]{6,})`)
+	bearerRegex       = regexp.MustCompile(`(?i)\b(?:bearer|basic)\s+([A-Za-z0-9\-._~+/]{12,}=*)`)
+
+	uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// RedactOptions controls how secrets are detected and replaced when writing a dump.
+type RedactOptions struct {
+	KeyPatterns    []string // Regular expressions for member names whose values are always redacted.
+	MinEntropy     float64  // Tokens with at least this entropy in bits per character are redacted, zero disables the heuristic.
+	MinTokenLength int      // Tokens shorter than this are never redacted by the entropy heuristic.
+	Salt           string   // Secret for deriving placeholders, see redactionSalt.
+}
+
+// DefaultRedactOptions returns the redaction options used by 'swimpeek dump -redact'.
+func DefaultRedactOptions() RedactOptions {
+	return RedactOptions{
+		KeyPatterns:    slices.Clone(DefaultRedactKeyPatterns),
+		MinEntropy:     DefaultRedactMinEntropy,
+		MinTokenLength: DefaultRedactMinTokenLength,
+	}
+}
+
+// RedactionInfo records in the dump header what was redacted.
+type RedactionInfo struct {
+	KeyPatterns    []string
+	MinEntropy     float64
+	MinTokenLength int
+	Redactions     []Redaction
+}
+
+// Redaction is a single redacted value.
+type Redaction struct {
+	Resource    string // Resource type and id, e.g. workflow <id>.
+	Path        string // Location of the value in the resource.
+	Reason      string // key, inline, or entropy.
+	Placeholder string // Placeholder that replaced the value.
+}
+
+// redactor replaces secrets with placeholders and records where they were found.
+type redactor struct {
+	keyPatterns    []*regexp.Regexp
+	minEntropy     float64
+	minTokenLength int
+	salt           []byte
+	info           *RedactionInfo
+	resource       string
+}
+
+// redactionSalt returns the salt for the placeholders. Placeholders must be stable across dumps so redacted dumps can be diffed,
+// without a user-supplied salt it is derived from the tenant id. A derived salt is not secret: anyone who knows the tenant id can
+// confirm a guessed value by its placeholder, pass a secret salt when the dump leaves the team.
+func redactionSalt(opts RedactOptions, tenantId string) []byte {
+	if opts.Salt != "" {
+		return []byte(opts.Salt)
+	}
+	return []byte("swimpeek-redaction/" + tenantId)
+}
+
+func newRedactor(opts RedactOptions, tenantId string) (*redactor, error) {
+	r := &redactor{
+		minEntropy:     opts.MinEntropy,
+		minTokenLength: opts.MinTokenLength,
+		salt:           redactionSalt(opts, tenantId),
+		info: &RedactionInfo{
+			KeyPatterns:    opts.KeyPatterns,
+			MinEntropy:     opts.MinEntropy,
+			MinTokenLength: opts.MinTokenLength,
+			Redactions:     make([]Redaction, 0),
+		},
+	}
+
+	for _, pattern := range opts.KeyPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", pattern, err)
+		}
+		r.keyPatterns = append(r.keyPatterns, re)
+	}
+	return r, nil
+}
+
+// placeholder returns the stable placeholder for a secret value.
+func (r *redactor) placeholder(value string) string {
+	mac := hmac.New(sha256.New, r.salt)
+	mac.Write([]byte(value))
+	return "REDACTED-" + hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+// record replaces a value with its placeholder and records the redaction.
+func (r *redactor) record(path string, reason string, value string) string {
+	placeholder := r.placeholder(value)
+	r.info.Redactions = append(r.info.Redactions, Redaction{
+		Resource:    r.resource,
+		Path:        path,
+		Reason:      reason,
+		Placeholder: placeholder,
+	})
+	return placeholder
+}
+
+// isSecretKey returns true if the member name matches one of the key patterns.
+func (r *redactor) isSecretKey(key string) bool {
+	for _, re := range r.keyPatterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns a redacted copy of a decoded JSON value, the original is not modified.
+func (r *redactor) value(path string, value any) any {
+	switch v := value.(type) {
+	case string:
+		return r.string(path, v)
+
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		redacted := make(map[string]any, len(v))
+		// Name/value pairs such as HTTP headers: {"key": "Authorization", "value": "..."}
+		secretPair := false
+		for _, nameKey := range []string{"key", "name", "header"} {
+			if name, ok := v[nameKey].(string); ok && r.isSecretKey(name) {
+				secretPair = true
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			memberPath := path + "." + key
+			str, isString := v[key].(string)
+			switch {
+			case isString && str != "" && r.isSecretKey(key):
+				redacted[key] = r.record(memberPath, "key", str)
+			case isString && str != "" && secretPair && key == "value":
+				redacted[key] = r.record(memberPath, "key", str)
+			default:
+				redacted[key] = r.value(memberPath, v[key])
+			}
+		}
+		return redacted
+
+	case []any:
+		if v == nil {
+			return v
+		}
+		redacted := make([]any, len(v))
+		for idx, item := range v {
+			redacted[idx] = r.value(fmt.Sprintf("%s[%d]", path, idx), item)
+		}
+		return redacted
+	}
+	return value
+}
+
+// string redacts inline secret assignments and high entropy tokens in a string.
+func (r *redactor) string(path string, value string) string {
+	replaceGroup := func(re *regexp.Regexp, reason string, s string) string {
+		matches := re.FindAllStringSubmatchIndex(s, -1)
+		for idx := len(matches) - 1; idx >= 0; idx-- {
+			start, end := matches[idx][2], matches[idx][3]
+			if strings.HasPrefix(s[start:end], "REDACTED-") {
+				continue
+			}
+			s = s[:start] + r.record(path, reason, s[start:end]) + s[end:]
+		}
+		return s
+	}
+	value = replaceGroup(inlineSecretRegex, "inline", value)
+	value = replaceGroup(bearerRegex, "inline", value)
+
+	if r.minEntropy <= 0 {
+		return value
+	}
+	return secretTokenRegex.ReplaceAllStringFunc(value, func(token string) string {
+		if r.isHighEntropy(token) {
+			return r.record(path, "entropy", token)
+		}
+		return token
+	})
+}
+
+// isHighEntropy returns true if the token looks like a generated secret.
+func (r *redactor) isHighEntropy(token string) bool {
+	if len(token) < r.minTokenLength || strings.HasPrefix(token, "REDACTED-") || uuidRegex.MatchString(token) {
+		return false
+	}
+	hasDigit := strings.ContainsAny(token, "0123456789")
+	hasLetter := strings.IndexFunc(token, func(c rune) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }) >= 0
+	return hasDigit && hasLetter && shannonEntropy(token) >= r.minEntropy
+}
+
+// shannonEntropy returns the entropy of a string in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, c := range s {
+		counts[c]++
+		total++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// action returns a redacted copy of a playbook action and its nested actions.
+func (r *redactor) action(path string, action laneclient.PlaybookAction) laneclient.PlaybookAction {
+	action.Inputs = r.value(path+".inputs", action.Inputs)
+	if action.Transformations != nil {
+		action.Transformations = r.value(path+".transformations", action.Transformations).(map[string]any)
+	}
+	action.Loop.Each = r.value(path+".loop.each", action.Loop.Each)
+
+	if action.Conditions != nil {
+		conditions := make([]laneclient.ActionCondition, len(action.Conditions))
+		for idx, cond := range action.Conditions {
+			condPath := fmt.Sprintf("%s.conditions[%d]", path, idx)
+			redacted := make(map[string][]any, len(cond.Condition))
+			for op, operands := range cond.Condition {
+				redacted[op] = r.value(condPath+"."+op, operands).([]any)
+			}
+			conditions[idx] = laneclient.ActionCondition{Action: cond.Action, Condition: redacted}
+		}
+		action.Conditions = conditions
+	}
+
+	if action.Actions != nil {
+		action.Actions = r.actions(path+".actions", action.Actions)
+	}
+	return action
+}
+
+// actions returns redacted copies of a set of playbook actions.
+func (r *redactor) actions(path string, actions map[string]laneclient.PlaybookAction) map[string]laneclient.PlaybookAction {
+	redacted := make(map[string]laneclient.PlaybookAction, len(actions))
+	for _, id := range slices.Sorted(maps.Keys(actions)) {
+		redacted[id] = r.action(path+"."+id, actions[id])
+	}
+	return redacted
+}
+
+// workflow returns a redacted copy of a workflow.
+func (r *redactor) workflow(workflow laneclient.Workflow) laneclient.Workflow {
+	workflow.Playbook.Actions = r.actions("playbook.actions", workflow.Playbook.Actions)
+	return workflow
+}
+
+// workflows returns redacted copies of a set of workflows.
+func (r *redactor) workflows(resource string, workflows map[string]laneclient.Workflow) map[string]laneclient.Workflow {
+	if workflows == nil {
+		return nil
+	}
+	redacted := make(map[string]laneclient.Workflow, len(workflows))
+	for _, id := range slices.Sorted(maps.Keys(workflows)) {
+		r.resource = resource + " " + id
+		redacted[id] = r.workflow(workflows[id])
+	}
+	return redacted
+}
+
+// redactState returns a copy of the state with secrets in workflows, revision history, legacy task scripts, and sensor settings and endpoints replaced by placeholders.
+// The copy shares all data that is not redacted with the original.
+func redactState(laneState *LaneState, opts RedactOptions) (*LaneState, error) {
+	r, err := newRedactor(opts, laneState.Tenant.Id)
+	if err != nil {
+		return nil, err
+	}
+	redacted := *laneState
+
+	redacted.WorkflowsById = r.workflows("workflow", laneState.WorkflowsById)
+
+	if laneState.HistoryBySolutionId != nil {
+		redacted.HistoryBySolutionId = make(map[string][]SolutionRevision, len(laneState.HistoryBySolutionId))
+		for _, solutionId := range slices.Sorted(maps.Keys(laneState.HistoryBySolutionId)) {
+			revisions := slices.Clone(laneState.HistoryBySolutionId[solutionId])
+			for idx, rev := range revisions {
+				revisions[idx].Workflows = r.workflows(fmt.Sprintf("revision %d of %s, workflow", rev.Version, solutionId), rev.Workflows)
+			}
+			redacted.HistoryBySolutionId[solutionId] = revisions
+		}
+	}
+
+	if laneState.LegacyTasksById != nil {
+		redacted.LegacyTasksById = make(map[string]laneclient.LegacyTask, len(laneState.LegacyTasksById))
+		for _, id := range slices.Sorted(maps.Keys(laneState.LegacyTasksById)) {
+			task := laneState.LegacyTasksById[id]
+			r.resource = "legacy task " + id
+			if task.Action.Descriptor != nil {
+				task.Action.Descriptor = r.value("action.descriptor", task.Action.Descriptor).(map[string]any)
+			}
+			redacted.LegacyTasksById[id] = task
+		}
+	}
+
+	if laneState.SensorsById != nil {
+		redacted.SensorsById = make(map[string]laneclient.Sensor, len(laneState.SensorsById))
+		for _, id := range slices.Sorted(maps.Keys(laneState.SensorsById)) {
+			r.resource = "sensor " + id
+			redacted.SensorsById[id] = r.sensor(laneState.SensorsById[id])
+		}
+	}
+
+	redacted.Header.Redaction = r.info
+	return &redacted, nil
+}
+
+// sensor returns a redacted copy of a sensor. Anyone who knows the endpoint of a webhook without authentication can call it,
+// so the path and the path of the URL are always replaced; the host is kept. The asset holding the credentials is a reference and kept.
+func (r *redactor) sensor(sensor laneclient.Sensor) laneclient.Sensor {
+	cfg := &sensor.Sensor
+	if cfg.Path != "" {
+		cfg.Path = r.record("sensor.path", "endpoint", cfg.Path)
+	}
+	if cfg.Url != "" {
+		if u, err := url.Parse(cfg.Url); err == nil && u.Host != "" {
+			endpoint := strings.TrimPrefix(u.RequestURI(), "/")
+			cfg.Url = (&url.URL{Scheme: u.Scheme, Host: u.Host}).String() + "/" + r.record("sensor.url", "endpoint", endpoint)
+		} else {
+			cfg.Url = r.record("sensor.url", "endpoint", cfg.Url)
+		}
+	}
+	cfg.Description = r.string("sensor.description", cfg.Description)
+	cfg.Auth.Header = r.string("sensor.auth.header", cfg.Auth.Header)
+	cfg.Extra = r.rawMembers("sensor", cfg.Extra)
+	return sensor
+}
+
+// rawMembers returns redacted copies of members that were kept as raw JSON.
+func (r *redactor) rawMembers(path string, members map[string]json.RawMessage) map[string]json.RawMessage {
+	if members == nil {
+		return nil
+	}
+	redacted := make(map[string]json.RawMessage, len(members))
+	for _, key := range slices.Sorted(maps.Keys(members)) {
+		var decoded any
+		if err := json.Unmarshal(members[key], &decoded); err != nil {
+			redacted[key] = members[key]
+			continue
+		}
+		// Redact as a member of an object so the key patterns apply, members without secrets are kept as they were
+		count := len(r.info.Redactions)
+		value := r.value(path, map[string]any{key: decoded}).(map[string]any)[key]
+		data, err := json.Marshal(value)
+		if err != nil || len(r.info.Redactions) == count {
+			redacted[key] = members[key]
+			continue
+		}
+		redacted[key] = data
+	}
+	return redacted
+}
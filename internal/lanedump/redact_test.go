@@ -0,0 +1,93 @@
+package lanedump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+func TestRedactEntropyTokens(t *testing.T) {
+	opts := DefaultRedactOptions()
+	opts.Salt = "test"
+	r, err := newRedactor(opts, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		value    string
+		redacted bool
+	}{
+		{"https://ec2-54-123-45-67.eu-west-1.compute.amazonaws.com/api/v2/incidents/search?limit=100", false},
+		{"tenant-7f3a.prod.eu1.swimlane-hosted-instances.example.com", false},
+		{"/var/lib/swimlane/integrations/python3.11/site-packages/requests/adapters.py", false},
+		{"send to https://hooks.example.com/services/T0AB12CD3/B0EF45GH6/x9Kq2Lm7Pz4Rt8Vw1Yb6Nc3D", true},
+		{"key: 9fQ2xL7mZ4pR8tV1wY6bN3cD0kH5jG", true},
+	} {
+		got := r.string("value", tc.value)
+		if redacted := strings.Contains(got, "REDACTED-"); redacted != tc.redacted {
+			t.Errorf("redacted = %v, want %v: %q -> %q", redacted, tc.redacted, tc.value, got)
+		}
+	}
+}
+
+func TestRedactStablePlaceholders(t *testing.T) {
+	state := func(tenantId string) *LaneState {
+		sensor := laneclient.Sensor{Id: "s1"}
+		sensor.Sensor.Path = "hooks/x9Kq2Lm7Pz4Rt8Vw1Yb6Nc3D"
+		return &LaneState{Tenant: laneclient.Tenant{Id: tenantId}, SensorsById: map[string]laneclient.Sensor{"s1": sensor}}
+	}
+	placeholder := func(laneState *LaneState, opts RedactOptions) string {
+		t.Helper()
+		redacted, err := redactState(laneState, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return redacted.SensorsById["s1"].Sensor.Path
+	}
+
+	// Dumps of a tenant must be diffable after redaction
+	opts := DefaultRedactOptions()
+	first := placeholder(state("t1"), opts)
+	if !strings.HasPrefix(first, "REDACTED-") {
+		t.Fatalf("path not redacted: %q", first)
+	}
+	if again := placeholder(state("t1"), opts); again != first {
+		t.Errorf("placeholder changed between dumps: %q, %q", first, again)
+	}
+	if other := placeholder(state("t2"), opts); other == first {
+		t.Errorf("placeholder of another tenant should differ: %q", other)
+	}
+
+	opts.Salt = "secret"
+	if salted := placeholder(state("t1"), opts); salted == first || salted != placeholder(state("t2"), opts) {
+		t.Errorf("a given salt must replace the tenant salt: %q", salted)
+	}
+}
+
+func TestRedactSensorEndpoint(t *testing.T) {
+	sensor := laneclient.Sensor{Id: "s1"}
+	sensor.Sensor.Path = "webhook/abc"
+	sensor.Sensor.Url = "https://tenant.example.com/webhook/abc?key=1"
+	sensor.Sensor.Auth = laneclient.SensorAuth{Type: "token", Header: "X-Token", AssetId: "a1"}
+	laneState := &LaneState{SensorsById: map[string]laneclient.Sensor{"s1": sensor}}
+
+	redacted, err := redactState(laneState, DefaultRedactOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := redacted.SensorsById["s1"].Sensor
+	if !strings.HasPrefix(cfg.Path, "REDACTED-") {
+		t.Errorf("path not redacted: %q", cfg.Path)
+	}
+	if !strings.HasPrefix(cfg.Url, "https://tenant.example.com/REDACTED-") {
+		t.Errorf("URL not redacted or host lost: %q", cfg.Url)
+	}
+	if cfg.Auth != sensor.Sensor.Auth {
+		t.Errorf("auth changed: %+v", cfg.Auth)
+	}
+	if laneState.SensorsById["s1"].Sensor.Path != "webhook/abc" {
+		t.Error("the original state was modified")
+	}
+}
@@ -0,0 +1,58 @@
+package lanedump
+
+import (
+	"time"
+
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+// RunMetrics summarizes the executions of a workflow or action.
+type RunMetrics struct {
+	Runs            int
+	Failures        int
+	Completed       int // Runs with a recorded duration, the average duration is taken over these.
+	AverageDuration time.Duration
+	totalDuration   time.Duration // Sum of completed run durations, only used while aggregating.
+}
+
+// WorkflowRunMetrics summarizes the executions of a workflow and its actions.
+type WorkflowRunMetrics struct {
+	RunMetrics
+	LastRun     time.Time
+	ActionsById map[string]RunMetrics
+}
+
+// HasRunHistory returns true if the state includes run metrics.
+func (s *LaneState) HasRunHistory() bool {
+	return s.RunMetricsByWorkflowId != nil
+}
+
+// add records a single execution.
+func (m *RunMetrics) add(failed bool, duration time.Duration) {
+	m.Runs++
+	if failed {
+		m.Failures++
+	}
+	if duration > 0 {
+		m.totalDuration += duration
+		m.Completed++
+		m.AverageDuration = m.totalDuration / time.Duration(m.Completed)
+	}
+}
+
+// addRun records a workflow run and the actions executed by it.
+func (m *WorkflowRunMetrics) addRun(run laneclient.PlaybookRun) {
+	m.add(run.Failed(), run.Duration())
+	if run.StartTime.After(m.LastRun) {
+		m.LastRun = run.StartTime
+	}
+
+	if m.ActionsById == nil {
+		m.ActionsById = make(map[string]RunMetrics)
+	}
+	for _, actRun := range run.Actions {
+		actMetrics := m.ActionsById[actRun.ActionId]
+		actMetrics.add(actRun.Failed(), actRun.Duration())
+		m.ActionsById[actRun.ActionId] = actMetrics
+	}
+}
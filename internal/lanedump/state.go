@@ -1,20 +1,59 @@
 package lanedump
 
 import (
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/just-oblivious/swimpeek/pkg/laneclient"
 )
 
+// LoadOptions controls which optional resources are fetched from the tenant.
+type LoadOptions struct {
+	WithIdentities bool          // Fetch account users, groups, and roles for access auditing.
+	RunsSince      time.Duration // Fetch the run history of this period to compute run metrics, zero disables run history.
+	Base           *LaneState    // Previous dump of the tenant, only changed workflows and applications are fetched when set.
+	BestEffort     bool          // Keep going when a resource type can't be fetched, the errors are recorded in the state.
+}
+
+// HasIdentities returns true if the state includes users, groups, and roles.
+func (s *LaneState) HasIdentities() bool {
+	return s.RolesById != nil
+}
+
 // LaneState holds the state of the SwimLane tenant.
 type LaneState struct {
+	Header             DumpHeader // Schema and tool versions, kept first so it can be read without decoding the dump.
 	TimeStamp          time.Time
 	Tenant             laneclient.Tenant                           // The tenant this state is for.
+	Profile            string                                      // Configuration profile used to create the dump, empty for dumps from before profiles.
 	PlaybooksById      map[string]laneclient.OrchestrationSolution // Playbooks are orchestration solutions referencing one or more workflows.
 	ComponentsById     map[string]laneclient.OrchestrationSolution // Components are orchestration solutions referencing exactly one workflow.
 	WorkflowsById      map[string]laneclient.Workflow              // Workflows describe the chain of actions to be performed (the "playbook").
 	ApplicationsById   map[string]laneclient.Application           // Applications define the shape of the records that can be referenced.
 	ConnectorsById     map[string]laneclient.Connector             // Connectors are called by workflows to perform a variety of actions.
 	SensorsById        map[string]laneclient.Sensor                // Sensors are event listeners like webhooks or flow events.
+	AssetsById         map[string]laneclient.Asset                 // Assets hold the credentials and connection details used by connector actions.
+	WorkspacesById     map[string]laneclient.Workspace             // Workspaces group applications and dashboards.
+	DashboardsById     map[string]laneclient.Dashboard             // Dashboards display one or more reports.
+	ReportsById        map[string]laneclient.Report                // Reports are saved searches over application records.
+	UsersById          map[string]laneclient.User                  // Account users, only present when dumped with identities.
+	GroupsById         map[string]laneclient.Group                 // Account groups, only present when dumped with identities.
+	RolesById          map[string]laneclient.Role                  // Account roles and their permissions, only present when dumped with identities.
 	OrchestrationTasks []laneclient.OrchestrationTask              // Orchestration tasks are references between workflows and applications (e.g. recordAction, playbookButton).
+	LegacyTasksById    map[string]laneclient.LegacyTask            // Legacy integration tasks from before Turbine.
+	LegacyTriggersById map[string]laneclient.LegacyTrigger         // Legacy triggers start legacy tasks on record changes, button presses, or schedules.
+
+	RunsSince              time.Time                     // Start of the run history period, only present when dumped with run history.
+	RunMetricsByWorkflowId map[string]WorkflowRunMetrics // Run metrics per workflow, only present when dumped with run history.
+
+	HistoryBySolutionId map[string][]SolutionRevision // Revisions of playbooks and components (newest first), added by the history command.
+
+	Refresh     *RefreshInfo      `json:",omitempty"` // What was fetched from the tenant, only present for incremental dumps.
+	FetchErrors map[string]string `json:",omitempty"` // Errors per resource type of a best-effort dump, the data of these types is missing or incomplete.
+}
+
+// MissingResources returns the sorted resource types that could not be fetched completely.
+func (s *LaneState) MissingResources() []string {
+	return slices.Sorted(maps.Keys(s.FetchErrors))
 }
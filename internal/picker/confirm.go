@@ -0,0 +1,31 @@
+package picker
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Confirm shows a yes/no confirmation dialog, aborting the dialog counts as "no".
+func Confirm(title string, description string) (bool, error) {
+	confirmed := false
+
+	confirmForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(title).
+				Description(description).
+				Affirmative("Yes").
+				Negative("No").
+				Value(&confirmed),
+		),
+	).WithTheme(huh.ThemeDracula())
+
+	if err := confirmForm.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return false, nil
+		}
+		return false, err
+	}
+	return confirmed, nil
+}
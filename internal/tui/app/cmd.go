@@ -22,6 +22,8 @@ const (
 	NavSelect
 	NavExpandAll
 	NavCollapseAll
+	NavToggle
+	NavHistory
 )
 
 type NavCmd struct {
@@ -67,6 +69,12 @@ func NavCmdExpandAll() tea.Msg {
 func NavCmdCollapseAll() tea.Msg {
 	return NavCmd{NavEvent: NavCollapseAll}
 }
+func NavCmdToggle() tea.Msg {
+	return NavCmd{NavEvent: NavToggle}
+}
+func NavCmdHistory() tea.Msg {
+	return NavCmd{NavEvent: NavHistory}
+}
 
 type FocusCmd struct {
 	Focus bool
@@ -107,6 +115,14 @@ func CmdShowDetails(node *graph.Node) tea.Msg {
 	return ShowDetailsCmd{Node: node}
 }
 
+type ShowHistoryCmd struct {
+	Node *graph.Node
+}
+
+func CmdShowHistory(node *graph.Node) tea.Msg {
+	return ShowHistoryCmd{Node: node}
+}
+
 type PushViewCmd struct {
 	View tea.Model
 }
@@ -114,3 +130,17 @@ type PushViewCmd struct {
 func CmdPushView(view tea.Model) tea.Msg {
 	return PushViewCmd{View: view}
 }
+
+type ToggleResourceCmd struct {
+	Node *graph.Node
+}
+
+func CmdToggleResource(node *graph.Node) tea.Msg {
+	return ToggleResourceCmd{Node: node}
+}
+
+type ToggleResultCmd struct {
+	Node   *graph.Node
+	Enable bool
+	Err    error
+}
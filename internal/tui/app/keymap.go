@@ -20,6 +20,10 @@ type KeyMap struct {
 	Collapse    key.Binding
 	ExpandAll   key.Binding
 	CollapseAll key.Binding
+	WriteMode   key.Binding
+	Toggle      key.Binding
+	Confirm     key.Binding
+	History     key.Binding
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -30,6 +34,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.NextTab, k.PrevTab},
 		{k.Expand, k.Collapse, k.ExpandAll, k.CollapseAll},
+		{k.History, k.WriteMode, k.Toggle},
 		{k.Back, k.Filter, k.Quit, k.Help},
 	}
 }
@@ -103,4 +108,20 @@ var Keys = KeyMap{
 		key.WithKeys("Z"),
 		key.WithHelp("Z", "collapse all nodes"),
 	),
+	WriteMode: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "toggle write mode"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "enable/disable (write mode)"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "confirm"),
+	),
+	History: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "revision history"),
+	),
 }
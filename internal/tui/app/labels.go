@@ -10,11 +10,20 @@ var NodeIcons map[graph.NodeType]string = map[graph.NodeType]string{
 	graph.WhileLoopAction:       "↻",
 
 	graph.FlowEventNode:   "✲",
+	graph.WebhookNode:     "⇲",
 	graph.ComponentNode:   "Σ",
 	graph.ApplicationNode: "⌘",
 	graph.ConnectorNode:   "⎋",
 	graph.WorkflowNode:    "▶",
 	graph.PlaybookNode:    "⎔",
+	graph.AssetNode:       "⚿",
+	graph.WorkspaceNode:   "▦",
+	graph.DashboardNode:   "◫",
+	graph.ReportNode:      "☰",
+	graph.UserNode:        "☺",
+	graph.GroupNode:       "☷",
+	graph.RoleNode:        "⚑",
+	graph.LegacyTaskNode:  "⚙",
 
 	graph.RecordCreateActionNode: "✚",
 	graph.RecordUpdateActionNode: "✎",
@@ -49,6 +58,16 @@ var NodeLabels map[graph.NodeType]string = map[graph.NodeType]string{
 	graph.RecordEventNode:          "record event",
 	graph.CronEventNode:            "cron event",
 	graph.WebhookNode:              "incoming webhook",
+	graph.FlowEventNode:            "flow event",
+	graph.AssetNode:                "asset",
+	graph.WorkspaceNode:            "workspace",
+	graph.DashboardNode:            "dashboard",
+	graph.ReportNode:               "report",
+	graph.UserNode:                 "user",
+	graph.GroupNode:                "group",
+	graph.RoleNode:                 "role",
+	graph.LegacyTaskNode:           "legacy task",
+	graph.LegacyTriggerNode:        "legacy trigger",
 }
 
 // edgeLabels provides human-readable labels for different edge types.
@@ -62,4 +81,10 @@ var EdgeLabels map[graph.EdgeType]string = map[graph.EdgeType]string{
 	graph.HasEventEdge:         "event",
 	graph.TriggersWorkflowEdge: "triggers",
 	graph.UnreachableEdge:      "unreachable",
+	graph.UsedByEdge:           "used by",
+	graph.ContainsEdge:         "contains",
+	graph.ReportedByEdge:       "reported by",
+	graph.DisplaysEdge:         "displays",
+	graph.TriggersTaskEdge:     "triggers task",
+	graph.ReferencesAppEdge:    "references",
 }
@@ -0,0 +1,19 @@
+package app
+
+import (
+	"context"
+
+	"github.com/just-oblivious/swimpeek/internal/graph"
+)
+
+// Writer applies changes to the live tenant, it is only available when the explorer was started with write access.
+type Writer interface {
+	// CanToggle returns true if the enabled state of the node can be changed.
+	CanToggle(node *graph.Node) bool
+	// IsEnabled returns the current enabled state of the node.
+	IsEnabled(node *graph.Node) bool
+	// SetEnabled enables or disables the resource represented by the node.
+	SetEnabled(ctx context.Context, node *graph.Node, enabled bool) error
+	// MarkEnabled updates the enabled state in the loaded dump after a successful change.
+	MarkEnabled(node *graph.Node, enabled bool)
+}
@@ -62,7 +62,9 @@ func sortAccessActions(actions []analyzer.AccessAction) {
 	}
 
 	pbLabelFn := func(action analyzer.AccessAction) string {
-		if action.Playbook != nil {
+		if action.Task != nil {
+			return action.Task.Meta.Label
+		} else if action.Playbook != nil {
 			return action.Playbook.Meta.Label
 		} else if action.Component != nil {
 			return action.Component.Meta.Label
@@ -97,6 +99,9 @@ func (m *accessListView) openWorkflow() tea.Msg {
 		return nil
 	}
 	act := m.accessActions[m.cursorIdx]
+	if act.IsLegacyAction() {
+		return nil // Legacy tasks have no flow to show.
+	}
 	if act.IsComponentAction() {
 		for ep := range m.analyzer.GetEntrypointsForWorkflow(act.Workflow) {
 			return app.CmdShowFlowWithHighlight(ep, act.Action, m.app, act.Component)
@@ -195,6 +200,7 @@ func (m *accessListView) renderAccessList() string {
 
 	playbookIcon := app.NodeIcons[graph.WorkflowNode]
 	componentIcon := app.NodeIcons[graph.ComponentNode]
+	legacyIcon := app.NodeIcons[graph.LegacyTaskNode]
 
 	formatActionContainerFn := func(action analyzer.AccessAction, highlighted bool) string {
 		style := styles.TableCellStyle
@@ -212,10 +218,20 @@ func (m *accessListView) renderAccessList() string {
 		if action.Enabled {
 			wfStyle = styles.ResEnabledStyle
 		}
+		if action.IsLegacyAction() {
+			state := "disabled"
+			if action.Enabled {
+				state = "enabled"
+			}
+			return fmt.Sprintf("%s%s %s (%s)", pfx, icnStyle.Render(legacyIcon), style.Render(action.Task.Meta.Label), wfStyle.Render("legacy, "+state))
+		}
 		return fmt.Sprintf("%s%s %s (%s)", pfx, icnStyle.Render(playbookIcon), style.Render(action.Playbook.Meta.Label), wfStyle.Render(action.Workflow.Meta.Label))
 	}
 
 	formatActionFn := func(action analyzer.AccessAction) string {
+		if action.IsLegacyAction() {
+			return ""
+		}
 		return "  " + styles.ResDescriptionStyle.Render(action.Action.Meta.Label)
 	}
 
@@ -17,13 +17,18 @@ func NewApplicationDetailsView(node *graph.Node, analyzer *analyzer.Analyzer, ou
 
 	appTriggers := analyzer.ApplicationTriggers(node)
 	appAccessLocations := analyzer.ApplicationAccessedBy(node)
+	appReports := analyzer.ApplicationReports(node)
+	appRelations := analyzer.ApplicationRelations(node)
 
-	labels := []string{"App Fields", "Record Actions", "Playbook Buttons", "Access Locations"}
+	labels := []string{"App Fields", "Record Actions", "Playbook Buttons", "Access Locations", "Reports", "Related Apps", "Permissions"}
 	sections := []tea.Model{
 		newApplicationFieldList(analyzer, innerFrame, outerFrame, appResource, node),
-		newTriggerList(innerFrame, appTriggers.RecordEventTriggers, node),
-		newTriggerList(innerFrame, appTriggers.ButtonTriggers, node),
+		newTriggerList(analyzer, innerFrame, appTriggers.RecordEventTriggers, node),
+		newTriggerList(analyzer, innerFrame, appTriggers.ButtonTriggers, node),
 		newAccessListView(analyzer, innerFrame, appAccessLocations, nil, node),
+		newReportListView(analyzer, innerFrame, appReports, appResource, node),
+		newRelatedAppsView(innerFrame, appRelations, node),
+		newPermissionListView(analyzer, innerFrame, appTriggers.ButtonTriggers, node),
 	}
 
 	return tabview.NewTabView(labels, sections, outerFrame, innerFrame)
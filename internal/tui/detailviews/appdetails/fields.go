@@ -31,27 +31,28 @@ func newApplicationFieldList(analyzer *analyzer.Analyzer, innerFrame *app.Frame,
 		analyzer:    analyzer,
 		innerFrame:  innerFrame,
 		outerFrame:  outerFrame,
-		fieldTable:  createFieldTable(appResource, innerFrame),
+		fieldTable:  createFieldTable(appResource, analyzer, innerFrame),
 		app:         app,
 		appResource: appResource,
 	}
 }
 
-func (m *applicationFieldList) openFieldAccess() tea.Msg {
-	if len(m.appResource.Fields) == 0 {
+// selectedField returns the field of the selected table row.
+func (m *applicationFieldList) selectedField() *laneclient.ApplicationField {
+	selectedRow := m.fieldTable.SelectedRow()
+	if selectedRow == nil {
 		return nil
 	}
-
-	selectedRow := m.fieldTable.SelectedRow()
-	fieldID := selectedRow[0]
-
-	var selectedField *laneclient.ApplicationField
 	for _, f := range m.appResource.Fields {
-		if f.Id == fieldID {
-			selectedField = &f
-			break
+		if f.Id == selectedRow[0] {
+			return &f
 		}
 	}
+	return nil
+}
+
+func (m *applicationFieldList) openFieldAccess() tea.Msg {
+	selectedField := m.selectedField()
 	if selectedField == nil {
 		return nil
 	}
@@ -92,12 +93,18 @@ func (m *applicationFieldList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m *applicationFieldList) View() string {
 	title := styles.TitleStyle.Render(m.app.Meta.Label+fmt.Sprintf(" - %d Fields", len(m.appResource.Fields))) + "\n"
 
-	m.fieldTable.SetHeight(m.innerFrame.Height - lipgloss.Height(title))
+	fieldDetails := m.renderFieldDetails()
+	detailsHeight := 0
+	if fieldDetails != "" {
+		detailsHeight = lipgloss.Height(fieldDetails)
+	}
+
+	m.fieldTable.SetHeight(m.innerFrame.Height - lipgloss.Height(title) - detailsHeight)
 	m.fieldTable.SetWidth(m.innerFrame.Width)
 
 	content := m.renderFieldTable()
 
-	return lipgloss.JoinVertical(lipgloss.Left, title, content)
+	return app.JoinVerticalNonEmpty(lipgloss.Left, title, content, fieldDetails)
 
 }
 
@@ -109,26 +116,116 @@ func (m *applicationFieldList) renderFieldTable() string {
 	return m.fieldTable.View()
 }
 
+// renderFieldDetails renders a panel with the schema of the selected field, only attributes that are set are shown.
+func (m *applicationFieldList) renderFieldDetails() string {
+	field := m.selectedField()
+	if field == nil {
+		return ""
+	}
+
+	width := m.innerFrame.Width - styles.FieldPanelStyle.GetHorizontalFrameSize()
+	lines := make([]string, 0)
+	addFn := func(label string, value string) {
+		line := styles.HelpKeyStyle.Render(label+": ") + value
+		lines = append(lines, lipgloss.NewStyle().Width(width).Render(line))
+	}
+
+	if field.HelpText != "" {
+		addFn("Help text", styles.ResDescriptionStyle.Render(field.HelpText))
+	}
+	if field.TargetId != "" {
+		target := field.TargetId
+		var targetFields map[string]string
+		if targetApp, exists := m.analyzer.Lanestate.ApplicationsById[field.TargetId]; exists {
+			target = targetApp.Name
+			targetFields = make(map[string]string, len(targetApp.Fields))
+			for _, f := range targetApp.Fields {
+				targetFields[f.Id] = f.Key
+			}
+		}
+		addFn("References", styles.ResReferenceStyle.Render(target))
+
+		columns := make([]string, 0, len(field.Columns))
+		for _, fieldId := range field.Columns {
+			if key, exists := targetFields[fieldId]; exists {
+				fieldId = key
+			}
+			columns = append(columns, fieldId)
+		}
+		if len(columns) > 0 {
+			addFn("Columns", strings.Join(columns, ", "))
+		}
+	}
+	if field.SelectionType != "" {
+		selection := field.SelectionType
+		if field.ControlType != "" {
+			selection += fmt.Sprintf(" (%s)", field.ControlType)
+		}
+		addFn("Selection", selection)
+	}
+	if len(field.Values) > 0 {
+		values := make([]string, 0, len(field.Values))
+		for _, value := range field.Values {
+			values = append(values, value.Name)
+		}
+		addFn(fmt.Sprintf("Values (%d)", len(values)), strings.Join(values, ", "))
+	}
+	if field.Calculated || field.Formula != "" {
+		addFn("Formula", styles.ResTriggerStyle.Render(field.Formula))
+	}
+	if defaults := field.DefaultValues(); len(defaults) > 0 {
+		addFn("Default", strings.Join(defaults, ", "))
+	} else if field.DefaultValueType != "" && field.DefaultValueType != "none" {
+		addFn("Default", field.DefaultValueType)
+	}
+	if field.Unique {
+		addFn("Unique", "values must be unique across records")
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	title := styles.BoldStyle.Render(field.Name)
+	return styles.FieldPanelStyle.Width(m.innerFrame.Width - styles.FieldPanelStyle.GetHorizontalBorderSize()).Render(lipgloss.JoinVertical(lipgloss.Left, append([]string{title}, lines...)...))
+}
+
 // createFieldTable creates a table model for displaying the application fields.
-func createFieldTable(app *laneclient.Application, frame *app.Frame) *table.Model {
+func createFieldTable(app *laneclient.Application, analyzer *analyzer.Analyzer, frame *app.Frame) *table.Model {
 	rows := make([]table.Row, len(app.Fields))
 
 	for idx, field := range app.Fields {
 
 		// Format field flags
-		flags := make([]string, 0, 2)
+		flags := make([]string, 0, 4)
 		if field.Required {
 			flags = append(flags, "REQ")
 		}
 		if field.ReadOnly {
 			flags = append(flags, "RO")
 		}
+		if field.Unique {
+			flags = append(flags, "UNIQ")
+		}
+		if field.Calculated {
+			flags = append(flags, "CALC")
+		}
 
 		// Append input type if available
 		fieldType := strings.TrimSuffix(strings.TrimPrefix(field.Type, "Core.Models.Fields."), ", Core")
 		if field.InputType != "" {
 			fieldType = fmt.Sprintf("%s (%s)", fieldType, field.InputType)
 		}
+		if field.SelectionType == "multi" {
+			fieldType += " [multi]"
+		}
+		if field.TargetId != "" {
+			target := field.TargetId
+			if targetApp, exists := analyzer.Lanestate.ApplicationsById[field.TargetId]; exists {
+				target = targetApp.Name
+			}
+			fieldType += " → " + target
+		}
 
 		row := table.Row{
 			field.Id,
@@ -0,0 +1,166 @@
+package appdetails
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type permissionListView struct {
+	frame         *app.Frame
+	hasIdentities bool
+	grants        []analyzer.AccessGrant
+	buttons       []*analyzer.PlaybookButtonGrantsResult
+	app           *graph.Node
+	cursorIdx     int
+	viewport      viewport.Model
+}
+
+// newPermissionListView creates a list view for displaying the roles with access to this application and who can run its playbook buttons.
+func newPermissionListView(analyzer *analyzer.Analyzer, frame *app.Frame, appButtons []analyzer.TriggerAction, app *graph.Node) tea.Model {
+	m := &permissionListView{
+		frame:         frame,
+		hasIdentities: analyzer.Lanestate.HasIdentities(),
+		app:           app,
+		viewport:      viewport.New(frame.Width-2, frame.Height),
+	}
+	if !m.hasIdentities {
+		return m
+	}
+
+	m.grants = analyzer.ResourceGrants(app)
+	for _, btn := range appButtons {
+		if btn.IsLegacyTrigger() {
+			continue // Legacy buttons run tasks, not playbooks.
+		}
+		m.buttons = append(m.buttons, analyzer.PlaybookButtonGrants(app, btn.Trigger))
+	}
+	return m
+}
+
+func (m *permissionListView) rowCount() int {
+	return len(m.grants) + len(m.buttons)
+}
+
+func (m *permissionListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *permissionListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case app.NavCmd:
+		switch msg.NavEvent {
+		case app.NavUp:
+			m.cursorIdx = max(0, m.cursorIdx-1)
+		case app.NavDown:
+			m.cursorIdx = max(0, min(m.rowCount()-1, m.cursorIdx+1))
+		case app.NavPageUp:
+			m.cursorIdx = max(0, m.cursorIdx-5)
+		case app.NavPageDown:
+			m.cursorIdx = max(0, min(m.rowCount()-1, m.cursorIdx+5))
+		case app.NavHome:
+			m.cursorIdx = 0
+		case app.NavEnd:
+			m.cursorIdx = max(0, m.rowCount()-1)
+		case app.NavLeft:
+			m.viewport.ScrollLeft(5)
+		case app.NavRight:
+			m.viewport.ScrollRight(5)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *permissionListView) View() string {
+	content := m.renderPermissionList()
+	title := styles.TitleStyle.Render(m.app.Meta.Label+fmt.Sprintf(" - %d Roles", len(m.grants))) + "\n"
+
+	m.viewport.SetContent(content)
+	m.viewport.Width = m.frame.Width - 2
+	m.viewport.Height = m.frame.Height - lipgloss.Height(title)
+	m.viewport.SetYOffset(m.cursorIdx)
+
+	scrollBar := styles.RenderScrollBar(&m.viewport)
+	contentPane := lipgloss.JoinHorizontal(lipgloss.Left, scrollBar, " ", m.viewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, contentPane)
+}
+
+// renderPermissionList renders the roles as role -> access (groups), followed by the playbook buttons as button -> users that can run it.
+func (m *permissionListView) renderPermissionList() string {
+	if !m.hasIdentities {
+		return styles.ResDescriptionStyle.Render("No identities in dump, use 'swimpeek dump -with-identities'")
+	}
+	if m.rowCount() == 0 {
+		return styles.ResDescriptionStyle.Render("No roles grant access to this application")
+	}
+
+	labelsFn := func(nodes []*graph.Node) string {
+		labels := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			labels = append(labels, node.Meta.Label)
+		}
+		return strings.Join(labels, ", ")
+	}
+
+	nameCol := make([]string, 0, m.rowCount())
+	accessCol := make([]string, 0, m.rowCount())
+	holderCol := make([]string, 0, m.rowCount())
+
+	rowStylesFn := func(idx int) (lipgloss.Style, lipgloss.Style) {
+		if idx == m.cursorIdx {
+			return styles.CursorStyle, styles.CursorStyle
+		}
+		return styles.TableCellStyle, styles.HelpDescStyle
+	}
+
+	for idx, grant := range m.grants {
+		cellStyle, sepStyle := rowStylesFn(idx)
+		nameCol = append(nameCol, styles.ResReferenceStyle.Render(grant.Role.Meta.Label))
+		accessCol = append(accessCol, sepStyle.Render(" ➜ ")+cellStyle.Render(grant.Access.String()))
+
+		holders := fmt.Sprintf("  %d users", len(grant.Users))
+		if len(grant.Groups) > 0 {
+			holders += fmt.Sprintf(" (%s)", labelsFn(grant.Groups))
+		}
+		holderCol = append(holderCol, styles.ResDescriptionStyle.Render(holders))
+	}
+
+	for idx, btn := range m.buttons {
+		cellStyle, sepStyle := rowStylesFn(len(m.grants) + idx)
+		nameCol = append(nameCol, styles.ResTriggerStyle.Render(btn.Button.Meta.Label))
+
+		runners := styles.ResDescriptionStyle.Render("nobody")
+		if len(btn.Runners) > 0 {
+			runners = cellStyle.Render(labelsFn(btn.Runners))
+		}
+		accessCol = append(accessCol, sepStyle.Render(" ➜ ")+runners)
+
+		restriction := ""
+		if len(btn.PlaybookRoles) > 0 {
+			roles := make([]*graph.Node, 0, len(btn.PlaybookRoles))
+			for _, grant := range btn.PlaybookRoles {
+				roles = append(roles, grant.Role)
+			}
+			restriction = styles.ResDescriptionStyle.Render("  playbook restricted to: " + labelsFn(roles))
+		}
+		holderCol = append(holderCol, restriction)
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.JoinVertical(lipgloss.Right, nameCol...),
+		lipgloss.JoinVertical(lipgloss.Left, accessCol...),
+		lipgloss.JoinVertical(lipgloss.Left, holderCol...),
+	)
+}
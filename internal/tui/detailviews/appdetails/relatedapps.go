@@ -0,0 +1,142 @@
+package appdetails
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// relatedApp is a row in the related applications list.
+type relatedApp struct {
+	relation analyzer.AppRelation
+	outgoing bool
+}
+
+type relatedAppsView struct {
+	frame     *app.Frame
+	related   []relatedApp
+	app       *graph.Node
+	cursorIdx int
+	viewport  viewport.Model
+}
+
+// newRelatedAppsView creates a list view for displaying the applications linked to this application through reference fields.
+func newRelatedAppsView(frame *app.Frame, relations *analyzer.ApplicationRelationsResult, app *graph.Node) tea.Model {
+	related := make([]relatedApp, 0, len(relations.References)+len(relations.ReferencedBy))
+	for _, rel := range relations.References {
+		related = append(related, relatedApp{relation: rel, outgoing: true})
+	}
+	for _, rel := range relations.ReferencedBy {
+		if rel.App == app {
+			continue // Self references are already listed as outgoing.
+		}
+		related = append(related, relatedApp{relation: rel, outgoing: false})
+	}
+
+	return &relatedAppsView{
+		frame:    frame,
+		related:  related,
+		app:      app,
+		viewport: viewport.New(frame.Width-2, frame.Height),
+	}
+}
+
+func (m *relatedAppsView) openApplication() tea.Msg {
+	if m.cursorIdx < 0 || m.cursorIdx >= len(m.related) {
+		return nil
+	}
+	return app.CmdShowDetails(m.related[m.cursorIdx].relation.App)
+}
+
+func (m *relatedAppsView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *relatedAppsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case app.NavCmd:
+		switch msg.NavEvent {
+		case app.NavUp:
+			m.cursorIdx = max(0, m.cursorIdx-1)
+		case app.NavDown:
+			m.cursorIdx = min(len(m.related)-1, m.cursorIdx+1)
+		case app.NavPageUp:
+			m.cursorIdx = max(0, m.cursorIdx-5)
+		case app.NavPageDown:
+			m.cursorIdx = min(len(m.related)-1, m.cursorIdx+5)
+		case app.NavHome:
+			m.cursorIdx = 0
+		case app.NavEnd:
+			m.cursorIdx = len(m.related) - 1
+		case app.NavLeft:
+			m.viewport.ScrollLeft(5)
+		case app.NavRight:
+			m.viewport.ScrollRight(5)
+		case app.NavSelect:
+			return m, m.openApplication
+		}
+	}
+
+	return m, nil
+}
+
+func (m *relatedAppsView) View() string {
+	content := m.renderRelatedApps()
+	title := styles.TitleStyle.Render(m.app.Meta.Label+fmt.Sprintf(" - %d Related Applications", len(m.related))) + "\n"
+
+	m.viewport.SetContent(content)
+	m.viewport.Width = m.frame.Width - 2
+	m.viewport.Height = m.frame.Height - lipgloss.Height(title)
+	m.viewport.SetYOffset(m.cursorIdx)
+
+	scrollBar := styles.RenderScrollBar(&m.viewport)
+	contentPane := lipgloss.JoinHorizontal(lipgloss.Left, scrollBar, " ", m.viewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, contentPane)
+}
+
+// renderRelatedApps renders the related applications as direction -> application, followed by the linking fields.
+func (m *relatedAppsView) renderRelatedApps() string {
+	if len(m.related) == 0 {
+		return styles.ResDescriptionStyle.Render("No related applications found")
+	}
+
+	directionCol := make([]string, len(m.related))
+	appCol := make([]string, len(m.related))
+	fieldCol := make([]string, len(m.related))
+
+	for idx, rel := range m.related {
+		appStyle := styles.TableCellStyle
+		if idx == m.cursorIdx {
+			appStyle = styles.CursorStyle
+		}
+
+		direction := "← referenced by"
+		if rel.outgoing {
+			direction = "→ references"
+		}
+		if rel.relation.App == m.app {
+			direction = "↺ references itself"
+		}
+
+		directionCol[idx] = styles.ResReferenceStyle.Render(direction) + " "
+		appCol[idx] = appStyle.Render(rel.relation.App.Meta.Label)
+		fieldCol[idx] = styles.ResDescriptionStyle.Render("  via " + strings.Join(rel.relation.Fields, ", "))
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.JoinVertical(lipgloss.Left, directionCol...),
+		lipgloss.JoinVertical(lipgloss.Left, appCol...),
+		lipgloss.JoinVertical(lipgloss.Left, fieldCol...),
+	)
+}
@@ -0,0 +1,150 @@
+package appdetails
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type reportListView struct {
+	analyzer    *analyzer.Analyzer
+	frame       *app.Frame
+	usages      []analyzer.ReportUsage
+	app         *graph.Node
+	appResource *laneclient.Application
+	cursorIdx   int
+	viewport    viewport.Model
+}
+
+// newReportListView creates a list view for displaying the reports and dashboards based on this application.
+func newReportListView(analyzer *analyzer.Analyzer, frame *app.Frame, usages []analyzer.ReportUsage, appResource *laneclient.Application, app *graph.Node) tea.Model {
+	return &reportListView{
+		analyzer:    analyzer,
+		frame:       frame,
+		usages:      usages,
+		app:         app,
+		appResource: appResource,
+		viewport:    viewport.New(frame.Width-2, frame.Height),
+	}
+}
+
+func (m *reportListView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *reportListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case app.NavCmd:
+		switch msg.NavEvent {
+		case app.NavUp:
+			m.cursorIdx = max(0, m.cursorIdx-1)
+		case app.NavDown:
+			m.cursorIdx = min(len(m.usages)-1, m.cursorIdx+1)
+		case app.NavPageUp:
+			m.cursorIdx = max(0, m.cursorIdx-5)
+		case app.NavPageDown:
+			m.cursorIdx = min(len(m.usages)-1, m.cursorIdx+5)
+		case app.NavHome:
+			m.cursorIdx = 0
+		case app.NavEnd:
+			m.cursorIdx = len(m.usages) - 1
+		case app.NavLeft:
+			m.viewport.ScrollLeft(5)
+		case app.NavRight:
+			m.viewport.ScrollRight(5)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *reportListView) View() string {
+	content := m.renderReportList()
+	title := styles.TitleStyle.Render(m.app.Meta.Label+fmt.Sprintf(" - %d Reports", len(m.usages))) + "\n"
+
+	m.viewport.SetContent(content)
+	m.viewport.Width = m.frame.Width - 2
+	m.viewport.Height = m.frame.Height - lipgloss.Height(title)
+	m.viewport.SetYOffset(m.cursorIdx)
+
+	scrollBar := styles.RenderScrollBar(&m.viewport)
+	contentPane := lipgloss.JoinHorizontal(lipgloss.Left, scrollBar, " ", m.viewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, contentPane)
+}
+
+// renderReportList renders the report list as report -> dashboards (workspaces), followed by the fields used by the report.
+func (m *reportListView) renderReportList() string {
+	if len(m.usages) == 0 {
+		return styles.ResDescriptionStyle.Render("No reports found")
+	}
+
+	fieldKeys := make(map[string]string, len(m.appResource.Fields))
+	for _, field := range m.appResource.Fields {
+		fieldKeys[field.Id] = field.Key
+	}
+
+	labelsFn := func(nodes []*graph.Node) string {
+		labels := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			labels = append(labels, node.Meta.Label)
+		}
+		return strings.Join(labels, ", ")
+	}
+
+	reportCol := make([]string, len(m.usages))
+	dashboardCol := make([]string, len(m.usages))
+	fieldCol := make([]string, len(m.usages))
+
+	for idx, usage := range m.usages {
+		reportStyle := styles.ResTriggerStyle
+		dbStyle := styles.TableCellStyle
+		sepStyle := styles.HelpDescStyle
+		if idx == m.cursorIdx {
+			dbStyle = styles.CursorStyle
+			sepStyle = styles.CursorStyle
+		}
+
+		reportCol[idx] = reportStyle.Render(usage.Report.Meta.Label)
+
+		dashboards := styles.ResDescriptionStyle.Render("not on a dashboard")
+		if len(usage.Dashboards) > 0 {
+			dashboards = dbStyle.Render(labelsFn(usage.Dashboards))
+			if len(usage.Workspaces) > 0 {
+				dashboards += styles.ResReferenceStyle.Render(fmt.Sprintf(" (%s)", labelsFn(usage.Workspaces)))
+			}
+		}
+		dashboardCol[idx] = sepStyle.Render(" ➜ ") + dashboards
+
+		if report := m.analyzer.GetReportResource(usage.Report); report != nil {
+			keys := make([]string, 0)
+			for fieldId := range report.FieldIds() {
+				if key, ok := fieldKeys[fieldId]; ok {
+					keys = append(keys, key)
+				}
+			}
+			slices.Sort(keys)
+			if len(keys) > 0 {
+				fieldCol[idx] = styles.ResDescriptionStyle.Render("  fields: " + strings.Join(keys, ", "))
+			}
+		}
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		lipgloss.JoinVertical(lipgloss.Right, reportCol...),
+		lipgloss.JoinVertical(lipgloss.Left, dashboardCol...),
+		lipgloss.JoinVertical(lipgloss.Left, fieldCol...),
+	)
+}
@@ -14,6 +14,7 @@ import (
 )
 
 type appTriggerList struct {
+	analyzer       *analyzer.Analyzer
 	frame          *app.Frame
 	triggerActions []analyzer.TriggerAction
 	app            *graph.Node
@@ -22,8 +23,9 @@ type appTriggerList struct {
 }
 
 // newTriggerList creates a list view for displaying trigger actions associated with an application.
-func newTriggerList(frame *app.Frame, triggerActions []analyzer.TriggerAction, app *graph.Node) tea.Model {
+func newTriggerList(analyzer *analyzer.Analyzer, frame *app.Frame, triggerActions []analyzer.TriggerAction, app *graph.Node) tea.Model {
 	return &appTriggerList{
+		analyzer:       analyzer,
 		frame:          frame,
 		triggerActions: triggerActions,
 		app:            app,
@@ -36,6 +38,9 @@ func (m *appTriggerList) openWorkflow() tea.Msg {
 		return nil
 	}
 	trig := m.triggerActions[m.cursorIdx]
+	if trig.IsLegacyTrigger() {
+		return nil // Legacy tasks have no flow to show.
+	}
 	return app.CmdShowFlow(trig.Trigger, m.app, trig.Playbook, trig.Workflow)
 }
 
@@ -62,6 +67,11 @@ func (m *appTriggerList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursorIdx = len(m.triggerActions) - 1
 		case app.NavSelect:
 			return m, m.openWorkflow
+		case app.NavToggle:
+			if len(m.triggerActions) > 0 {
+				trigger := m.triggerActions[m.cursorIdx].Trigger
+				return m, func() tea.Msg { return app.CmdToggleResource(trigger) }
+			}
 		case app.NavLeft:
 			m.viewport.ScrollLeft(5)
 		case app.NavRight:
@@ -98,12 +108,6 @@ func (m *appTriggerList) renderTriggerList() string {
 	wfTriggers := make([]string, 0, len(m.triggerActions))
 
 	for idx, trig := range m.triggerActions {
-		wfStyle := styles.ResDisabledStyle
-		if trig.Enabled {
-			wfStyle = styles.ResEnabledStyle
-		}
-
-		trigStyle := styles.ResTriggerStyle
 		pbStyle := styles.TableCellStyle
 		sepStyle := styles.HelpDescStyle
 
@@ -113,6 +117,27 @@ func (m *appTriggerList) renderTriggerList() string {
 		}
 		sep := sepStyle.Render(" ➜ ")
 
+		if trig.IsLegacyTrigger() {
+			taskStyle := styles.ResDisabledStyle
+			if trig.Enabled {
+				taskStyle = styles.ResEnabledStyle
+			}
+			wfLabels = append(wfLabels, styles.ResTriggerStyle.Render(trig.Trigger.Meta.Label))
+			wfTriggers = append(wfTriggers, fmt.Sprintf("%s%s %s", sep, pbStyle.Render(app.NodeIcons[graph.LegacyTaskNode]+" "+trig.Task.Meta.Label), taskStyle.Render("(legacy task)")))
+			continue
+		}
+
+		// The workflow state is looked up on render, it may be changed in write mode
+		wfStyle := styles.ResDisabledStyle
+		if wf := m.analyzer.GetWorkflowResource(trig.Workflow); wf != nil && wf.Meta.Enabled {
+			wfStyle = styles.ResEnabledStyle
+		}
+
+		trigStyle := styles.ResTriggerStyle
+		if task := m.analyzer.GetOrchestrationTaskResource(trig.Trigger); task != nil && task.Disabled {
+			trigStyle = styles.ResDisabledStyle
+		}
+
 		wfLabels = append(wfLabels, trigStyle.Render(trig.Trigger.Meta.Label))
 		wfTriggers = append(wfTriggers, fmt.Sprintf("%s%s (%s)", sep, pbStyle.Render(trig.Playbook.Meta.Label), wfStyle.Render(trig.Workflow.Meta.Label)))
 	}
@@ -0,0 +1,40 @@
+package appdetails
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+)
+
+func TestRenderLegacyTrigger(t *testing.T) {
+	node := func(id string, typ graph.NodeType, label string) *graph.Node {
+		return &graph.Node{Meta: graph.Meta{Id: id, Type: typ, Label: label}}
+	}
+	appNode := node("a1", graph.ApplicationNode, "Alerts")
+	triggerActions := []analyzer.TriggerAction{
+		{
+			Trigger:  node("b1", graph.PlaybookButtonNode, "Enrich"),
+			Playbook: node("p1", graph.PlaybookNode, "Enrichment"),
+			Workflow: node("w1", graph.WorkflowNode, "Enrich alert"),
+		},
+		// Legacy triggers have no playbook or workflow
+		{
+			Trigger: node("t1", graph.LegacyTriggerNode, "Legacy button"),
+			Task:    node("k1", graph.LegacyTaskNode, "Legacy task"),
+			Enabled: true,
+		},
+	}
+
+	frame := &app.Frame{Width: 120, Height: 10}
+	list := newTriggerList(analyzer.NewAnalyzer(&lanedump.LaneState{}, nil), frame, triggerActions, appNode)
+	view := list.View()
+	for _, want := range []string{"Enrich alert", "Legacy button", "Legacy task", "(legacy task)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view does not contain %q:\n%s", want, view)
+		}
+	}
+}
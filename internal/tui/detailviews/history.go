@@ -0,0 +1,142 @@
+package detailviews
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanediff"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type HistoryView struct {
+	node      *graph.Node
+	frame     *app.Frame
+	revisions []lanedump.SolutionRevision
+	diffs     [][]lanediff.WorkflowDiff
+	cursorIdx int
+	viewport  viewport.Model
+}
+
+// NewHistoryView creates a view listing the revisions of a playbook or component, the selected revision shows its changes compared to the revision before it.
+func NewHistoryView(node *graph.Node, frame *app.Frame, revisions []lanedump.SolutionRevision) *HistoryView {
+	// Revisions are ordered newest first, the oldest revision has nothing to compare against
+	diffs := make([][]lanediff.WorkflowDiff, len(revisions))
+	for idx := 0; idx < len(revisions)-1; idx++ {
+		diffs[idx] = lanediff.DiffRevisions(revisions[idx+1], revisions[idx])
+	}
+
+	return &HistoryView{
+		node:      node,
+		frame:     frame,
+		revisions: revisions,
+		diffs:     diffs,
+		viewport:  viewport.New(frame.Width-2, frame.Height),
+	}
+}
+
+func (m *HistoryView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *HistoryView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case app.NavCmd:
+		switch msg.NavEvent {
+		case app.NavUp:
+			m.cursorIdx = max(0, m.cursorIdx-1)
+		case app.NavDown:
+			m.cursorIdx = min(len(m.revisions)-1, m.cursorIdx+1)
+		case app.NavPageUp:
+			m.cursorIdx = max(0, m.cursorIdx-5)
+		case app.NavPageDown:
+			m.cursorIdx = min(len(m.revisions)-1, m.cursorIdx+5)
+		case app.NavHome:
+			m.cursorIdx = 0
+		case app.NavEnd:
+			m.cursorIdx = len(m.revisions) - 1
+		case app.NavLeft:
+			m.viewport.ScrollLeft(5)
+		case app.NavRight:
+			m.viewport.ScrollRight(5)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *HistoryView) View() string {
+	content := m.renderRevisions()
+	title := styles.TitleStyle.Render(m.node.Meta.Label+fmt.Sprintf(" - %d Revisions", len(m.revisions))) + "\n"
+
+	m.viewport.SetContent(content)
+	m.viewport.Width = m.frame.Width - 2
+	m.viewport.Height = m.frame.Height - lipgloss.Height(title)
+	m.viewport.SetYOffset(m.cursorIdx)
+
+	scrollBar := styles.RenderScrollBar(&m.viewport)
+	contentPane := lipgloss.JoinHorizontal(lipgloss.Left, scrollBar, " ", m.viewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, contentPane)
+}
+
+// renderRevisions renders one line per revision, the selected revision is expanded with its workflow changes.
+func (m *HistoryView) renderRevisions() string {
+	lines := make([]string, 0, len(m.revisions))
+
+	for idx, rev := range m.revisions {
+		cursorPfx := "  "
+		versionStyle := styles.ResTriggerStyle
+		if idx == m.cursorIdx {
+			cursorPfx = styles.CursorStyle.Render("❯ ")
+			versionStyle = styles.CursorStyle
+		}
+
+		header := cursorPfx + versionStyle.Render(fmt.Sprintf("v%d", rev.Version)) +
+			styles.ResDescriptionStyle.Render(fmt.Sprintf("  %s  %s", rev.ModifiedDate.Format(time.DateTime), rev.ModifiedBy.Name))
+		if rev.Comment != "" {
+			header += "  " + rev.Comment
+		}
+		lines = append(lines, header)
+
+		if idx == m.cursorIdx {
+			lines = append(lines, m.renderChanges(idx))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderChanges renders the workflow changes of a revision, colored by the kind of change.
+func (m *HistoryView) renderChanges(idx int) string {
+	if idx == len(m.revisions)-1 {
+		return styles.ResDetailsStyle.Render(styles.ResDescriptionStyle.Render("    oldest revision in dump"))
+	}
+	if len(m.diffs[idx]) == 0 {
+		return styles.ResDetailsStyle.Render(styles.ResDescriptionStyle.Render("    no workflow changes"))
+	}
+
+	lines := make([]string, 0)
+	for _, diff := range m.diffs[idx] {
+		lines = append(lines, "    "+styles.BoldStyle.Render("● "+diff.Title))
+		for _, line := range diff.Summary() {
+			style := styles.ResReferenceStyle
+			switch {
+			case strings.HasPrefix(line, "+"):
+				style = styles.ResEnabledStyle
+			case strings.HasPrefix(line, "-"):
+				style = styles.ResDisabledStyle
+			}
+			lines = append(lines, "      "+style.Render(line))
+		}
+	}
+	return styles.ResDetailsStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
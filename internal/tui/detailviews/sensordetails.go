@@ -0,0 +1,217 @@
+package detailviews
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type SensorDetailsView struct {
+	node      *graph.Node
+	frame     *app.Frame
+	analyzer  *analyzer.Analyzer
+	sensor    *laneclient.Sensor
+	usage     *analyzer.SensorUsageResult
+	cursorIdx int
+	viewport  viewport.Model
+}
+
+// NewSensorDetailsView creates a view with the configuration of a webhook or flow event, and the playbooks consuming or emitting it.
+func NewSensorDetailsView(node *graph.Node, analyzer *analyzer.Analyzer, frame *app.Frame, sensor *laneclient.Sensor) *SensorDetailsView {
+	return &SensorDetailsView{
+		node:     node,
+		frame:    frame,
+		analyzer: analyzer,
+		sensor:   sensor,
+		usage:    analyzer.SensorUsage(node),
+		viewport: viewport.New(frame.Width-2, frame.Height),
+	}
+}
+
+func (m *SensorDetailsView) openWorkflow() tea.Msg {
+	if len(m.usage.Triggers) == 0 {
+		return nil
+	}
+	trig := m.usage.Triggers[m.cursorIdx]
+	return app.CmdShowFlow(trig.Trigger, trig.Playbook, trig.Workflow)
+}
+
+func (m *SensorDetailsView) Init() tea.Cmd {
+	return nil
+}
+
+func (m *SensorDetailsView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case app.NavCmd:
+		switch msg.NavEvent {
+		case app.NavUp:
+			m.cursorIdx = max(0, m.cursorIdx-1)
+		case app.NavDown:
+			m.cursorIdx = max(0, min(len(m.usage.Triggers)-1, m.cursorIdx+1))
+		case app.NavHome:
+			m.cursorIdx = 0
+		case app.NavEnd:
+			m.cursorIdx = max(0, len(m.usage.Triggers)-1)
+		case app.NavPageUp:
+			m.viewport.ScrollUp(5)
+		case app.NavPageDown:
+			m.viewport.ScrollDown(5)
+		case app.NavLeft:
+			m.viewport.ScrollLeft(5)
+		case app.NavRight:
+			m.viewport.ScrollRight(5)
+		case app.NavSelect:
+			return m, m.openWorkflow
+		}
+	}
+
+	return m, nil
+}
+
+func (m *SensorDetailsView) View() string {
+	title := styles.TitleStyle.Render(fmt.Sprintf("%s - %s", m.node.Meta.Label, app.NodeLabels[m.node.Meta.Type])) + "\n"
+
+	config := m.renderConfig()
+	consumers := m.renderConsumers()
+	content := app.JoinVerticalNonEmpty(lipgloss.Left, config, "", consumers, "", m.renderEmitters(), "", m.renderPayloadSchema())
+
+	m.viewport.SetContent(content)
+	m.viewport.Width = m.frame.Width - 2
+	m.viewport.Height = m.frame.Height - lipgloss.Height(title)
+
+	// Keep the selected consumer in view, the configuration is shown above the consumers
+	cursorLine := lipgloss.Height(config) + 2 + m.cursorIdx
+	if cursorLine >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(cursorLine - m.viewport.Height + 1)
+	} else if cursorLine < m.viewport.YOffset {
+		m.viewport.SetYOffset(cursorLine)
+	}
+
+	scrollBar := styles.RenderScrollBar(&m.viewport)
+	contentPane := lipgloss.JoinHorizontal(lipgloss.Left, scrollBar, " ", m.viewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, contentPane)
+}
+
+// renderConfig renders the sensor properties, unauthenticated webhooks are highlighted.
+func (m *SensorDetailsView) renderConfig() string {
+	lines := make([]string, 0)
+	addFn := func(label string, value string) {
+		lines = append(lines, styles.HelpKeyStyle.Render(fmt.Sprintf("%-12s", label))+value)
+	}
+
+	cfg := m.sensor.Sensor
+	addFn("Name", cfg.Name)
+	addFn("Type", cfg.Type)
+
+	state := styles.ResDisabledStyle.Render("disabled")
+	if m.sensor.Meta.Enabled {
+		state = styles.ResEnabledStyle.Render("enabled")
+	}
+	addFn("State", state)
+
+	if m.node.Meta.Type == graph.WebhookNode {
+		endpoint := cfg.Endpoint()
+		if endpoint == "" {
+			endpoint = styles.ResDescriptionStyle.Render("not in dump")
+		}
+		addFn("Endpoint", endpoint)
+
+		auth := styles.ErrorMsgStyle.Render("none, anyone with the URL can trigger it")
+		if cfg.IsAuthenticated() {
+			auth = cfg.Auth.Type
+			if cfg.Auth.Header != "" {
+				auth += fmt.Sprintf(" (header %s)", cfg.Auth.Header)
+			}
+			if asset, exists := m.analyzer.Lanestate.AssetsById[cfg.Auth.AssetId]; exists {
+				auth += styles.ResReferenceStyle.Render(" ← " + asset.Asset.Title)
+			}
+		}
+		addFn("Auth", auth)
+	}
+
+	if cfg.Description != "" {
+		addFn("Description", styles.ResDescriptionStyle.Render(cfg.Description))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderConsumers renders the playbook-workflows started by the sensor as playbook (workflow).
+func (m *SensorDetailsView) renderConsumers() string {
+	header := styles.BoldStyle.Render("Triggers playbooks:")
+	if len(m.usage.Triggers) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, styles.ResDescriptionStyle.Render("  No playbooks found"))
+	}
+
+	rows := make([]string, 0, len(m.usage.Triggers))
+	for idx, trig := range m.usage.Triggers {
+		pfx := "  "
+		pbStyle := styles.TableCellStyle
+		if idx == m.cursorIdx {
+			pfx = styles.CursorStyle.Render("❯ ")
+			pbStyle = styles.CursorStyle
+		}
+		wfStyle := styles.ResDisabledStyle
+		if trig.Enabled {
+			wfStyle = styles.ResEnabledStyle
+		}
+		rows = append(rows, fmt.Sprintf("%s%s (%s)", pfx, pbStyle.Render(trig.Playbook.Meta.Label), wfStyle.Render(trig.Workflow.Meta.Label)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, rows...)...)
+}
+
+// renderEmitters renders the playbooks and components emitting a flow event.
+func (m *SensorDetailsView) renderEmitters() string {
+	if m.node.Meta.Type != graph.FlowEventNode {
+		return ""
+	}
+
+	rows := make([]string, 0)
+	for _, pbNode := range analyzer.SortSetByLabel(m.usage.EmitterWorkflows) {
+		wfLabels := make([]string, 0)
+		for _, wfNode := range analyzer.SortSetByLabel(m.usage.EmitterWorkflows[pbNode]) {
+			wfLabels = append(wfLabels, wfNode.Meta.Label)
+		}
+		rows = append(rows, fmt.Sprintf("  %s %s (%s)", app.NodeIcons[graph.PlaybookNode], pbNode.Meta.Label, strings.Join(wfLabels, ", ")))
+	}
+	for _, compNode := range analyzer.SortSetByLabel(m.usage.EmitterComponents) {
+		rows = append(rows, fmt.Sprintf("  %s %s", app.NodeIcons[graph.ComponentNode], compNode.Meta.Label))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, styles.ResDescriptionStyle.Render("  Not emitted by any playbook or component"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{styles.BoldStyle.Render("Emitted by:")}, rows...)...)
+}
+
+// renderPayloadSchema renders the properties of the expected payload, required properties are marked with *.
+func (m *SensorDetailsView) renderPayloadSchema() string {
+	header := styles.BoldStyle.Render("Payload schema:")
+	fields := m.sensor.Sensor.PayloadFields()
+	if len(fields) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, styles.ResDescriptionStyle.Render("  No schema defined"))
+	}
+
+	rows := make([]string, 0, len(fields))
+	for _, field := range fields {
+		name := field.Path
+		if field.Required {
+			name += "*"
+		}
+		rows = append(rows, fmt.Sprintf("  %s %s", name, styles.ResTypeLabelStyle.Render(field.Type)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, rows...)...)
+}
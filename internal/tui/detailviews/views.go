@@ -1,6 +1,8 @@
 package detailviews
 
 import (
+	"fmt"
+
 	"github.com/just-oblivious/swimpeek/internal/analyzer"
 	"github.com/just-oblivious/swimpeek/internal/graph"
 	"github.com/just-oblivious/swimpeek/internal/tui/app"
@@ -14,6 +16,7 @@ type DetailViews struct {
 	frame    *app.Frame
 	analyzer *analyzer.Analyzer
 	views    map[*graph.Node]tea.Model
+	history  map[*graph.Node]tea.Model
 }
 
 // NewDetailViews creates a manager for detail views, it handles dependency injection and caching of views.
@@ -22,6 +25,7 @@ func NewDetailViews(frame *app.Frame, analyzer *analyzer.Analyzer) *DetailViews
 		frame:    frame,
 		analyzer: analyzer,
 		views:    make(map[*graph.Node]tea.Model),
+		history:  make(map[*graph.Node]tea.Model),
 	}
 }
 
@@ -43,6 +47,13 @@ func (dv *DetailViews) ShowDetails(node *graph.Node) tea.Model {
 			break
 		}
 		detailView = appdetails.NewApplicationDetailsView(node, dv.analyzer, dv.frame, &vp, app)
+	case graph.WebhookNode, graph.FlowEventNode:
+		sensor := dv.analyzer.GetSensorResource(node)
+		if sensor == nil {
+			detailView = NewFallbackDetailsView(node, dv.frame, "Sensor data not found")
+			break
+		}
+		detailView = NewSensorDetailsView(node, dv.analyzer, dv.frame, sensor)
 	default:
 		detailView = NewFallbackDetailsView(node, dv.frame, "No detail view available for this resource type")
 	}
@@ -51,3 +62,26 @@ func (dv *DetailViews) ShowDetails(node *graph.Node) tea.Model {
 
 	return detailView
 }
+
+// ShowHistory returns a revision history view for the given playbook or component node.
+func (dv *DetailViews) ShowHistory(node *graph.Node) tea.Model {
+	if view, exists := dv.history[node]; exists {
+		return view
+	}
+
+	var historyView tea.Model
+	revisions := dv.analyzer.GetSolutionHistory(node)
+	if len(revisions) == 0 {
+		flags := "-dump <dump>"
+		if node.Meta.Type == graph.ComponentNode {
+			flags += " -component"
+		}
+		historyView = NewFallbackDetailsView(node, dv.frame, fmt.Sprintf("No revision history in dump, use 'swimpeek history %s %s'", flags, node.Meta.Id))
+	} else {
+		historyView = NewHistoryView(node, dv.frame, revisions)
+	}
+
+	dv.history[node] = historyView
+
+	return historyView
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
 	"github.com/just-oblivious/swimpeek/internal/tui/app"
 	"github.com/just-oblivious/swimpeek/internal/tui/styles"
 
@@ -16,18 +17,20 @@ type flowNode struct {
 	branches     []*flowNode
 	innerActions []*flowNode
 	references   map[*graph.Node]bool
+	runs         *lanedump.RunMetrics
 	hasFocus     bool
 	isExpanded   bool
 }
 
 // newFlowNode creates a new flow node.
-func newFlowNode(node *graph.Node, edge *graph.Edge, branches []*flowNode, innerActions []*flowNode, refs map[*graph.Node]bool, expanded bool) *flowNode {
+func newFlowNode(node *graph.Node, edge *graph.Edge, branches []*flowNode, innerActions []*flowNode, refs map[*graph.Node]bool, runs *lanedump.RunMetrics, expanded bool) *flowNode {
 	return &flowNode{
 		node:         node,
 		edge:         edge,
 		branches:     branches,
 		innerActions: innerActions,
 		references:   refs,
+		runs:         runs,
 		hasFocus:     false,
 		isExpanded:   expanded,
 	}
@@ -61,7 +64,7 @@ func (m *flowNode) render() string {
 	if !ok {
 		icon = "●"
 	}
-	label := m.renderEdge(icon) + m.renderNodeLabel() + m.renderReferences()
+	label := m.renderEdge(icon) + m.renderNodeLabel() + m.renderReferences() + m.renderRunMetrics()
 
 	blocks := make([]string, 0, 2)
 
@@ -159,6 +162,14 @@ func (m flowNode) renderReferences() string {
 	return styles.ResReferenceStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, " ➜ ", strings.Join(refs, " · ")))
 }
 
+// renderRunMetrics renders the run metrics overlay of this node, if the dump includes run history.
+func (m flowNode) renderRunMetrics() string {
+	if m.runs == nil {
+		return ""
+	}
+	return "  " + styles.FormatRunMetrics(m.runs.Runs, m.runs.Failures, m.runs.AverageDuration)
+}
+
 // renderLineSegments renders the vertical line segments connecting this node to its children.
 func (m flowNode) renderLineSegments(blocks []string, offset int) string {
 	border := lipgloss.RoundedBorder()
@@ -3,6 +3,7 @@ package flowtree
 import (
 	"github.com/just-oblivious/swimpeek/internal/analyzer"
 	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
 	"github.com/just-oblivious/swimpeek/internal/tui/app"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -33,7 +34,7 @@ func (fv *FlowViews) ShowFlow(rootNode *graph.Node, breadcrumbs []*graph.Node, h
 	}
 	vp := viewport.New(fv.frame.Width, fv.frame.Height)
 
-	flowNode := fv.createFlow(nil, rootNode)
+	flowNode := fv.createFlow(nil, rootNode, nil)
 	flowView := newFlowTree(fv.analyzer, fv.frame, flowNode, &vp)
 
 	fv.flows[rootNode] = flowView
@@ -42,40 +43,52 @@ func (fv *FlowViews) ShowFlow(rootNode *graph.Node, breadcrumbs []*graph.Node, h
 	return flowView
 }
 
-// createFlow creates a flow view for the given node and its branches.
-func (fv FlowViews) createFlow(edge *graph.Edge, node *graph.Node) *flowNode {
+// createFlow creates a flow view for the given node and its branches, wfNode is the workflow containing the node (if known).
+func (fv FlowViews) createFlow(edge *graph.Edge, node *graph.Node, wfNode *graph.Node) *flowNode {
 	expanded := true
 
 	// Find refs to components, applications, actions, etc.
 	refs := fv.analyzer.GetReferences(node)
 
+	// Find run metrics for the workflow or action, the workflow becomes the context of the actions below it
+	var runs *lanedump.RunMetrics
+	if node.Meta.Type == graph.WorkflowNode {
+		wfNode = node
+		if wfRuns := fv.analyzer.WorkflowRunMetrics(node); wfRuns != nil {
+			runs = &wfRuns.RunMetrics
+		}
+	} else if wfNode != nil {
+		runs = fv.analyzer.ActionRunMetrics(wfNode, node)
+	}
+
 	// Render inner flows
 	innerNodes := analyzer.NewWalkOpts(analyzer.Descend, analyzer.WithFollowEdgeTypes(graph.EntrypointEdge)).Next(node)
 
+	innerActions := fv.createBranches(innerNodes, wfNode)
+
 	// Integrate component workflow as inner nodes, but leave it collapsed by default
 	if node.Meta.Type == graph.ComponentActionNode {
 		expanded = false
 		compWf := fv.analyzer.GetWorkflowForComponent(fv.analyzer.GetComponentForAction(node))
 		if compWf != nil {
-			innerNodes = append(innerNodes, analyzer.NewWalkOpts(analyzer.Descend).Next(compWf)...)
+			innerActions = append(innerActions, fv.createBranches(analyzer.NewWalkOpts(analyzer.Descend).Next(compWf), compWf)...)
 		}
 
 	}
-	innerActions := fv.createBranches(innerNodes)
 
 	// Render branches
 	branchNodes := analyzer.NewWalkOpts(analyzer.Descend, analyzer.WithSkipEdgeTypes(graph.EntrypointEdge)).Next(node)
-	branchActions := fv.createBranches(branchNodes)
+	branchActions := fv.createBranches(branchNodes, wfNode)
 
-	return newFlowNode(node, edge, branchActions, innerActions, refs, expanded)
+	return newFlowNode(node, edge, branchActions, innerActions, refs, runs, expanded)
 }
 
 // createBranches creates flow views for a list of nodes connected by edges.
-func (fv FlowViews) createBranches(nodes []map[*graph.Edge]*graph.Node) []*flowNode {
+func (fv FlowViews) createBranches(nodes []map[*graph.Edge]*graph.Node, wfNode *graph.Node) []*flowNode {
 	branches := make([]*flowNode, 0, len(nodes))
 	for _, n := range nodes {
 		for edge, node := range n {
-			branch := fv.createFlow(edge, node)
+			branch := fv.createFlow(edge, node, wfNode)
 			branches = append(branches, branch)
 		}
 	}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/just-oblivious/swimpeek/internal/analyzer"
@@ -19,13 +20,13 @@ import (
 
 type createResViewFn func(string, *graph.Node, *analyzer.Analyzer, bool) tea.Model
 
-// LaunchExplorer launches the TUI resource explorer application
-func LaunchExplorer(laneState *lanedump.LaneState, graph *graph.Graph) error {
+// LaunchExplorer launches the TUI resource explorer application, writer enables write mode and may be nil.
+func LaunchExplorer(laneState *lanedump.LaneState, graph *graph.Graph, writer app.Writer) error {
 
 	windowStack := make([]tea.Model, 1)
 	analyzer := analyzer.NewAnalyzer(laneState, graph)
 
-	tabLabels := []string{"Playbooks", "Components", "Applications"}
+	tabLabels := []string{"Playbooks", "Components", "Applications", "Assets", "Sensors"}
 	windowFrame := app.NewFrame()
 	tabContentFrame := app.NewFrame()
 
@@ -33,6 +34,8 @@ func LaunchExplorer(laneState *lanedump.LaneState, graph *graph.Graph) error {
 		layout.NewListView(createListItemViews(graph.Resources.PlaybooksById, analyzer, listviews.NewPbListItem), tabContentFrame),
 		layout.NewListView(createListItemViews(graph.Resources.ComponentsById, analyzer, listviews.NewCompListItem), tabContentFrame),
 		layout.NewListView(createListItemViews(graph.Resources.AppsById, analyzer, listviews.NewSimpleListItem), tabContentFrame),
+		layout.NewListView(createListItemViews(graph.Resources.AssetsById, analyzer, listviews.NewAssetListItem), tabContentFrame),
+		layout.NewListView(createListItemViews(sensorNodes(graph), analyzer, listviews.NewSensorListItem), tabContentFrame),
 	}
 
 	flowViews := flowtree.NewFlowViews(windowFrame, analyzer)
@@ -40,7 +43,14 @@ func LaunchExplorer(laneState *lanedump.LaneState, graph *graph.Graph) error {
 
 	windowStack[0] = tabview.NewTabView(tabLabels, tabViews, windowFrame, tabContentFrame)
 	windowTitle := fmt.Sprintf("SwimPeek - %s (%s)", laneState.Tenant.Name, laneState.TimeStamp.Format(time.DateTime))
-	mainView := layout.NewMainView(windowTitle, windowStack, windowFrame, flowViews, detailViews)
+	if laneState.Profile != "" {
+		windowTitle += fmt.Sprintf(" [profile: %s]", laneState.Profile)
+	}
+	banner := ""
+	if missing := laneState.MissingResources(); len(missing) > 0 {
+		banner = fmt.Sprintf("Incomplete dump, missing data: %s", strings.Join(missing, ", "))
+	}
+	mainView := layout.NewMainView(windowTitle, banner, windowStack, windowFrame, flowViews, detailViews, writer)
 
 	if _, err := tea.NewProgram(mainView, tea.WithAltScreen()).Run(); err != nil {
 		return err
@@ -48,6 +58,17 @@ func LaunchExplorer(laneState *lanedump.LaneState, graph *graph.Graph) error {
 	return nil
 }
 
+// sensorNodes returns the webhook and flow event nodes, these are kept with the other trigger nodes.
+func sensorNodes(g *graph.Graph) map[string]*graph.Node {
+	nodes := make(map[string]*graph.Node)
+	for name, node := range g.Resources.TriggersById {
+		if node.Meta.Type == graph.WebhookNode || node.Meta.Type == graph.FlowEventNode {
+			nodes[name] = node
+		}
+	}
+	return nodes
+}
+
 // flattenAndSort flattens a map of graph nodes into a sorted slice
 func flattenAndSort(nodes map[string]*graph.Node) []*graph.Node {
 	nodeList := make([]*graph.Node, 0, len(nodes))
@@ -1,6 +1,11 @@
 package layout
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/just-oblivious/swimpeek/internal/graph"
 	"github.com/just-oblivious/swimpeek/internal/tui/app"
 	"github.com/just-oblivious/swimpeek/internal/tui/detailviews"
 	"github.com/just-oblivious/swimpeek/internal/tui/flowtree"
@@ -15,6 +20,7 @@ import (
 type mainView struct {
 	keys         app.KeyMap
 	title        string
+	banner       string
 	width        int
 	height       int
 	windowStack  []tea.Model
@@ -22,20 +28,34 @@ type mainView struct {
 	contentFrame *app.Frame
 	flowViews    *flowtree.FlowViews
 	detailViews  *detailviews.DetailViews
+	writer       app.Writer
+	writeMode    bool
+	pending      *pendingToggle
+	status       string
+}
+
+// pendingToggle is a change awaiting confirmation by the user.
+type pendingToggle struct {
+	node   *graph.Node
+	enable bool
 }
 
-func NewMainView(title string, windowStack []tea.Model, frame *app.Frame, flowViews *flowtree.FlowViews, detailViews *detailviews.DetailViews) tea.Model {
+// NewMainView creates the main application view, writer may be nil if the explorer was started without write access.
+// The banner is shown below the title when not empty, e.g. to point out data missing from the dump.
+func NewMainView(title string, banner string, windowStack []tea.Model, frame *app.Frame, flowViews *flowtree.FlowViews, detailViews *detailviews.DetailViews, writer app.Writer) tea.Model {
 	h := help.New()
 	h.Styles = styles.HelpStyles()
 
 	return mainView{
 		keys:         app.Keys,
 		title:        title,
+		banner:       banner,
 		windowStack:  windowStack,
 		help:         h,
 		contentFrame: frame,
 		flowViews:    flowViews,
 		detailViews:  detailViews,
+		writer:       writer,
 	}
 }
 
@@ -66,6 +86,18 @@ func (m mainView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// A pending change is applied on confirmation, any other key cancels it
+		if m.pending != nil {
+			pending := m.pending
+			m.pending = nil
+			if key.Matches(msg, m.keys.Confirm) {
+				m.status = fmt.Sprintf("Applying change to %s...", pending.node.Meta.Label)
+				return m, m.applyToggle(pending)
+			}
+			m.status = "Change cancelled"
+			return m, nil
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.NextTab):
 			return m.updateContent(app.NavCmdNextTab())
@@ -93,6 +125,22 @@ func (m mainView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateContent(app.NavCmdExpandAll())
 		case key.Matches(msg, m.keys.CollapseAll):
 			return m.updateContent(app.NavCmdCollapseAll())
+		case key.Matches(msg, m.keys.History):
+			return m.updateContent(app.NavCmdHistory())
+		case key.Matches(msg, m.keys.WriteMode):
+			if m.writer == nil {
+				m.status = "Write mode is not available, start the analyzer with 'swimpeek analyze -write'"
+				return m, nil
+			}
+			m.writeMode = !m.writeMode
+			m.status = ""
+			return m, nil
+		case key.Matches(msg, m.keys.Toggle):
+			if !m.writeMode {
+				m.status = "Enable write mode (W) to change resources"
+				return m, nil
+			}
+			return m.updateContent(app.NavCmdToggle())
 
 		// Quit active content first before quitting the application, this avoids the user from accidentally quitting
 		// the app when they meant to go back to the previous view :)
@@ -118,17 +166,69 @@ func (m mainView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.windowStack = append(m.windowStack, m.detailViews.ShowDetails(msg.Node))
 		return m, nil
 
+	case app.ShowHistoryCmd:
+		m.windowStack = append(m.windowStack, m.detailViews.ShowHistory(msg.Node))
+		return m, nil
+
 	case app.PushViewCmd:
 		m.windowStack = append(m.windowStack, msg.View)
 		return m, nil
+
+	case app.ToggleResourceCmd:
+		if !m.writeMode {
+			return m, nil
+		}
+		if !m.writer.CanToggle(msg.Node) {
+			m.status = fmt.Sprintf("%s can't be enabled or disabled", msg.Node.Meta.Label)
+			return m, nil
+		}
+		m.pending = &pendingToggle{node: msg.Node, enable: !m.writer.IsEnabled(msg.Node)}
+		verb := "Disable"
+		if m.pending.enable {
+			verb = "Enable"
+		}
+		m.status = fmt.Sprintf("%s %s %s in the live tenant? Press y to confirm, any other key cancels", verb, msg.Node.Meta.Type, msg.Node.Meta.Label)
+		return m, nil
+
+	case app.ToggleResultCmd:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Failed to change %s: %s", msg.Node.Meta.Label, msg.Err)
+			return m, nil
+		}
+		m.writer.MarkEnabled(msg.Node, msg.Enable)
+		state := "disabled"
+		if msg.Enable {
+			state = "enabled"
+		}
+		m.status = fmt.Sprintf("%s %s", msg.Node.Meta.Label, state)
+		return m, nil
 	}
 
 	return m.updateContent(msg)
 }
 
+// applyToggle returns a command that applies a confirmed change to the tenant.
+func (m mainView) applyToggle(pending *pendingToggle) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err := m.writer.SetEnabled(ctx, pending.node, pending.enable)
+		return app.ToggleResultCmd{Node: pending.node, Enable: pending.enable, Err: err}
+	}
+}
+
 func (m mainView) View() string {
 	title := styles.TitleStyle.Render(m.title)
+	if m.writeMode {
+		title = lipgloss.JoinHorizontal(lipgloss.Left, styles.WriteModeStyle.Render("WRITE MODE"), " ", title)
+	}
+	if m.banner != "" {
+		title = lipgloss.JoinVertical(lipgloss.Center, title, styles.BannerStyle.Render(m.banner))
+	}
 	usage := m.help.View(m.keys)
+	if m.status != "" {
+		usage = lipgloss.JoinVertical(lipgloss.Center, styles.StatusStyle.Render(m.status), usage)
+	}
 
 	m.contentFrame.Height = m.height - styles.WindowStyle.GetVerticalFrameSize() - lipgloss.Height(title) - lipgloss.Height(usage)
 	m.contentFrame.Width = m.width - styles.WindowStyle.GetHorizontalFrameSize()
@@ -0,0 +1,114 @@
+package listviews
+
+import (
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type assetListItem struct {
+	label      string
+	asset      *graph.Node
+	analyzer   *analyzer.Analyzer
+	hasFocus   bool
+	isExpanded bool
+	usedBy     *analyzer.AssetUsedByResult
+}
+
+// NewAssetListItem creates a new expandable list item for an asset node
+func NewAssetListItem(label string, assetNode *graph.Node, analyzer *analyzer.Analyzer, focused bool) tea.Model {
+	return assetListItem{
+		label:    label,
+		asset:    assetNode,
+		analyzer: analyzer,
+		hasFocus: focused,
+	}
+}
+
+func (m *assetListItem) expand() {
+	if m.usedBy == nil {
+		m.usedBy = m.analyzer.AssetUsedBy(m.asset)
+	}
+	m.isExpanded = true
+}
+
+func (m *assetListItem) collapse() {
+	m.isExpanded = false
+}
+
+func (m assetListItem) Init() tea.Cmd {
+	return nil
+}
+
+func (m assetListItem) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case app.FocusCmd:
+		m.hasFocus = msg.Focus
+
+	case app.NavCmd:
+		switch msg.NavEvent {
+		case app.NavRight:
+			m.expand()
+		case app.NavLeft:
+			m.collapse()
+		case app.NavSelect:
+			if m.isExpanded {
+				m.collapse()
+			} else {
+				m.expand()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m assetListItem) View() string {
+	if m.isExpanded {
+		title := m.compactView()
+		detailBlock := styles.ResDetailsStyle.Padding(0, 0, 1, 2).Render(m.detailedView())
+		return lipgloss.JoinVertical(lipgloss.Left, title, detailBlock)
+	}
+	return m.compactView()
+}
+
+func (m assetListItem) compactView() string {
+	if m.hasFocus {
+		return styles.CursorStyle.Render(m.label)
+	}
+	return m.label
+}
+
+func (m assetListItem) detailedView() string {
+	description := styles.FormatDescription(m.asset.Meta.Description, true)
+
+	usedByComponentsSection := lipgloss.JoinVertical(lipgloss.Left,
+		styles.BoldStyle.Render("Used by components:"),
+		styles.IndentLeft(1).Render(renderNodeList(m.usedBy.Components)),
+	)
+
+	usedByPlaybooksSection := lipgloss.JoinVertical(lipgloss.Left,
+		styles.BoldStyle.Render("Used by playbooks:"),
+		styles.IndentLeft(1).Render(renderPlaybookWorkflows(m.usedBy.PlaybookWorkflows)),
+	)
+
+	indirectSection := lipgloss.JoinVertical(lipgloss.Left,
+		styles.BoldStyle.Render("Used by playbooks through components:"),
+		styles.IndentLeft(1).Render(renderPlaybookWorkflows(m.usedBy.IndirectPlaybookWorkflows)),
+	)
+
+	sections := []string{description, usedByComponentsSection, "", usedByPlaybooksSection, "", indirectSection}
+	if len(m.usedBy.LegacyTasks) > 0 {
+		legacySection := lipgloss.JoinVertical(lipgloss.Left,
+			styles.BoldStyle.Render("Used by legacy tasks:"),
+			styles.IndentLeft(1).Render(renderNodeList(m.usedBy.LegacyTasks)),
+		)
+		sections = append(sections, "", legacySection)
+	}
+
+	return app.JoinVerticalNonEmpty(lipgloss.Top, sections...)
+}
@@ -69,6 +69,8 @@ func (m compListItem) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.collapse()
 		case app.NavSelect:
 			return m, m.openComponent
+		case app.NavHistory:
+			return m, func() tea.Msg { return app.CmdShowHistory(m.component) }
 		}
 	}
 
@@ -99,71 +101,19 @@ func (m compListItem) detailedView() string {
 }
 
 func (m compListItem) renderCallLocations() string {
-
-	// Format the components section
-	calledByComponents := make([]string, 0, len(m.calledBy.Components))
-	for _, comp := range analyzer.SortSetByLabel(m.calledBy.Components) {
-		calledByComponents = append(calledByComponents, comp.Meta.Label)
-	}
-
-	calledByComponentsList := styles.ResDescriptionStyle.Render("None")
-	if len(calledByComponents) > 0 {
-		calledByComponentsList = styles.ResReferenceStyle.Render(lipgloss.JoinVertical(lipgloss.Left, calledByComponents...))
-	}
 	calledByComponentsSection := lipgloss.JoinVertical(lipgloss.Left,
 		styles.BoldStyle.Render("Called by components:"),
-		styles.IndentLeft(1).Render(calledByComponentsList),
+		styles.IndentLeft(1).Render(renderNodeList(m.calledBy.Components)),
 	)
 
-	// Format the playbook-workflows section
-	calledByPlaybooks := make([]string, 0, len(m.calledBy.PlaybookWorkflows))
-	for pbIdx, pb := range analyzer.SortSetByLabel(m.calledBy.PlaybookWorkflows) {
-		wfs := analyzer.SortSetByLabel(m.calledBy.PlaybookWorkflows[pb])
-		wfLabels := make([]string, 0, len(wfs))
-		for wfIdx, wf := range wfs {
-			pfx := "├─"
-			if wfIdx == len(wfs)-1 {
-				pfx = "╰─"
-			}
-			wfLabels = append(wfLabels, styles.ResReferenceStyle.Render(pfx+wf.Meta.Label))
-		}
-
-		sfx := "\n"
-		if pbIdx == len(m.calledBy.PlaybookWorkflows)-1 {
-			sfx = ""
-		}
-		wfList := lipgloss.JoinVertical(lipgloss.Left, wfLabels...)
-		pbSection := lipgloss.JoinVertical(lipgloss.Left,
-			styles.ResReferenceStyle.Bold(true).Render(pb.Meta.Label),
-			wfList+sfx,
-		)
-
-		calledByPlaybooks = append(calledByPlaybooks, pbSection)
-	}
-
-	calledByPlaybookList := styles.ResDescriptionStyle.Render("None")
-	if len(calledByPlaybooks) > 0 {
-		calledByPlaybookList = lipgloss.JoinVertical(lipgloss.Left, calledByPlaybooks...)
-	}
-
 	calledByPlaybookSection := lipgloss.JoinVertical(lipgloss.Left,
 		styles.BoldStyle.Render("Called by playbooks:"),
-		styles.IndentLeft(1).Render(lipgloss.JoinVertical(lipgloss.Left, calledByPlaybookList)),
+		styles.IndentLeft(1).Render(renderPlaybookWorkflows(m.calledBy.PlaybookWorkflows)),
 	)
 
-	// Format the calls section
-	callLabels := make([]string, 0, len(m.calls))
-	for _, call := range analyzer.SortSetByLabel(m.calls) {
-		callLabels = append(callLabels, call.Meta.Label)
-	}
-
-	callList := styles.ResDescriptionStyle.Render("None")
-	if len(callLabels) > 0 {
-		callList = styles.ResReferenceStyle.Render(lipgloss.JoinVertical(lipgloss.Left, callLabels...))
-	}
 	callsComponentsSection := lipgloss.JoinVertical(lipgloss.Left,
 		styles.BoldStyle.Render("Calls components:"),
-		styles.IndentLeft(1).Render(callList),
+		styles.IndentLeft(1).Render(renderNodeList(m.calls)),
 	)
 
 	return lipgloss.JoinVertical(lipgloss.Left, calledByComponentsSection, "", calledByPlaybookSection, "", callsComponentsSection)
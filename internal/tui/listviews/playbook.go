@@ -3,9 +3,11 @@ package listviews
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/just-oblivious/swimpeek/internal/analyzer"
 	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
 	"github.com/just-oblivious/swimpeek/internal/tui/app"
 	"github.com/just-oblivious/swimpeek/internal/tui/styles"
 
@@ -18,6 +20,7 @@ type pbListItem struct {
 	playbook    *graph.Node
 	pbWorkflows []map[*graph.Node][]*graph.Node
 	analyzer    *analyzer.Analyzer
+	runs        *lanedump.RunMetrics
 	hasFocus    bool
 	isExpanded  bool
 	selectedIdx int
@@ -29,6 +32,7 @@ func NewPbListItem(label string, pbNode *graph.Node, analyzer *analyzer.Analyzer
 		label:    label,
 		playbook: pbNode,
 		analyzer: analyzer,
+		runs:     analyzer.PlaybookRunMetrics(pbNode),
 		hasFocus: focused,
 	}
 }
@@ -65,6 +69,16 @@ func (m pbListItem) openWorkflow() tea.Msg {
 	return nil
 }
 
+func (m pbListItem) toggleWorkflow() tea.Msg {
+	if len(m.pbWorkflows) == 0 || m.selectedIdx < 0 || m.selectedIdx >= len(m.pbWorkflows) {
+		return nil
+	}
+	for wf := range m.pbWorkflows[m.selectedIdx] {
+		return app.CmdToggleResource(wf)
+	}
+	return nil
+}
+
 func (m pbListItem) Init() tea.Cmd {
 	return nil
 }
@@ -90,6 +104,12 @@ func (m pbListItem) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.openWorkflow
 			}
 			m.expand()
+		case app.NavToggle:
+			if m.isExpanded {
+				return m, m.toggleWorkflow
+			}
+		case app.NavHistory:
+			return m, func() tea.Msg { return app.CmdShowHistory(m.playbook) }
 		case app.NavUp:
 			inBounds := m.cursorStepInBounds(-1)
 			if m.isExpanded && inBounds {
@@ -118,10 +138,14 @@ func (m pbListItem) View() string {
 }
 
 func (m pbListItem) compactView() string {
+	label := m.label
 	if m.hasFocus {
-		return styles.CursorStyle.Render(m.label)
+		label = styles.CursorStyle.Render(m.label)
 	}
-	return m.label
+	if m.runs != nil {
+		label += "  " + styles.FormatRunMetrics(m.runs.Runs, m.runs.Failures, m.runs.AverageDuration)
+	}
+	return label
 }
 
 func (m pbListItem) detailedView() string {
@@ -147,6 +171,12 @@ func (m pbListItem) renderWorkflowDetails() string {
 				}
 			}
 			wfLabel := style.Bold(selected).Render(fmt.Sprintf("● %s", wfNode.Meta.Label))
+			if runs := m.analyzer.WorkflowRunMetrics(wfNode); runs != nil {
+				wfLabel += "  " + styles.FormatRunMetrics(runs.Runs, runs.Failures, runs.AverageDuration)
+				if !runs.LastRun.IsZero() {
+					wfLabel += styles.ResDescriptionStyle.Render(" last run " + runs.LastRun.Format(time.DateTime))
+				}
+			}
 
 			trigDetails := m.renderTriggerDetails(wfTriggers)
 			wfDescription := styles.FormatDescription(wfNode.Meta.Description, false)
@@ -0,0 +1,55 @@
+package listviews
+
+import (
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderNodeList renders a sorted list of node labels.
+func renderNodeList(nodes map[*graph.Node]bool) string {
+	labels := make([]string, 0, len(nodes))
+	for _, node := range analyzer.SortSetByLabel(nodes) {
+		labels = append(labels, node.Meta.Label)
+	}
+
+	if len(labels) == 0 {
+		return styles.ResDescriptionStyle.Render("None")
+	}
+	return styles.ResReferenceStyle.Render(lipgloss.JoinVertical(lipgloss.Left, labels...))
+}
+
+// renderPlaybookWorkflows renders a sorted tree of playbooks and their workflows.
+func renderPlaybookWorkflows(pbWorkflows map[*graph.Node]map[*graph.Node]bool) string {
+	playbooks := make([]string, 0, len(pbWorkflows))
+	for pbIdx, pb := range analyzer.SortSetByLabel(pbWorkflows) {
+		wfs := analyzer.SortSetByLabel(pbWorkflows[pb])
+		wfLabels := make([]string, 0, len(wfs))
+		for wfIdx, wf := range wfs {
+			pfx := "├─"
+			if wfIdx == len(wfs)-1 {
+				pfx = "╰─"
+			}
+			wfLabels = append(wfLabels, styles.ResReferenceStyle.Render(pfx+wf.Meta.Label))
+		}
+
+		sfx := "\n"
+		if pbIdx == len(pbWorkflows)-1 {
+			sfx = ""
+		}
+		wfList := lipgloss.JoinVertical(lipgloss.Left, wfLabels...)
+		pbSection := lipgloss.JoinVertical(lipgloss.Left,
+			styles.ResReferenceStyle.Bold(true).Render(pb.Meta.Label),
+			wfList+sfx,
+		)
+
+		playbooks = append(playbooks, pbSection)
+	}
+
+	if len(playbooks) == 0 {
+		return styles.ResDescriptionStyle.Render("None")
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, playbooks...)
+}
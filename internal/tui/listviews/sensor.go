@@ -0,0 +1,65 @@
+package listviews
+
+import (
+	"github.com/just-oblivious/swimpeek/internal/analyzer"
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/internal/tui/styles"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type sensorListItem struct {
+	label    string
+	sensor   *graph.Node
+	resource *laneclient.Sensor
+	hasFocus bool
+}
+
+// NewSensorListItem creates a selectable list item for a webhook or flow event node, unauthenticated webhooks are flagged.
+func NewSensorListItem(label string, sensorNode *graph.Node, analyzer *analyzer.Analyzer, focused bool) tea.Model {
+	return sensorListItem{
+		label:    label,
+		sensor:   sensorNode,
+		resource: analyzer.GetSensorResource(sensorNode),
+		hasFocus: focused,
+	}
+}
+
+func (m sensorListItem) Init() tea.Cmd {
+	return nil
+}
+
+func (m sensorListItem) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case app.FocusCmd:
+		m.hasFocus = msg.Focus
+	case app.NavCmd:
+		switch msg.NavEvent {
+		case app.NavSelect:
+			return m, func() tea.Msg { return app.CmdShowDetails(m.sensor) }
+		}
+	}
+
+	return m, nil
+}
+
+func (m sensorListItem) View() string {
+	label := app.NodeIcons[m.sensor.Meta.Type] + " " + m.label
+	if m.hasFocus {
+		label = styles.CursorStyle.Render(label)
+	}
+
+	label += styles.ResDescriptionStyle.Render("  " + app.NodeLabels[m.sensor.Meta.Type])
+	if m.resource == nil {
+		return label
+	}
+	if !m.resource.Meta.Enabled {
+		label += " " + styles.ResDisabledStyle.Render("disabled")
+	}
+	if m.sensor.Meta.Type == graph.WebhookNode && !m.resource.Sensor.IsAuthenticated() {
+		label += " " + styles.ErrorMsgStyle.Render("unauthenticated")
+	}
+	return label
+}
@@ -1,7 +1,9 @@
 package styles
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/just-oblivious/swimpeek/internal/tui/app"
 
@@ -27,3 +29,23 @@ func FormatDescription(description string, addNewline bool) string {
 	}
 	return ResDescriptionStyle.Render(trimmed)
 }
+
+// FormatRunMetrics formats run metrics as a compact overlay, e.g. "⟳ 120 ✖ 3 ⌀ 1.2s".
+func FormatRunMetrics(runs int, failures int, avgDuration time.Duration) string {
+	if runs == 0 {
+		return ResDescriptionStyle.Render("⟳ no runs")
+	}
+
+	overlay := ResDescriptionStyle.Render(fmt.Sprintf("⟳ %d", runs))
+	if failures > 0 {
+		overlay += " " + ErrorMsgStyle.Render(fmt.Sprintf("✖ %d", failures))
+	}
+	if avgDuration > 0 {
+		precision := 100 * time.Millisecond
+		if avgDuration < time.Second {
+			precision = time.Millisecond
+		}
+		overlay += " " + ResDescriptionStyle.Render("⌀ "+avgDuration.Round(precision).String())
+	}
+	return overlay
+}
@@ -30,10 +30,14 @@ func HelpStyles() help.Styles {
 
 // Window styles
 var (
-	WindowStyle    = lipgloss.NewStyle().Padding(0, 2)
-	ErrorBoxStyle  = lipgloss.NewStyle().Padding(0, 2).Border(lipgloss.RoundedBorder()).BorderForeground(ErrorColor)
-	ScrollBarStyle = lipgloss.NewStyle().Foreground(ScrollBarColor)
-	ModeBlockStyle = lipgloss.NewStyle().Padding(0, 1).Background(FrameColor).Bold(true)
+	WindowStyle     = lipgloss.NewStyle().Padding(0, 2)
+	ErrorBoxStyle   = lipgloss.NewStyle().Padding(0, 2).Border(lipgloss.RoundedBorder()).BorderForeground(ErrorColor)
+	FieldPanelStyle = lipgloss.NewStyle().Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(FrameColor)
+	ScrollBarStyle  = lipgloss.NewStyle().Foreground(ScrollBarColor)
+	ModeBlockStyle  = lipgloss.NewStyle().Padding(0, 1).Background(FrameColor).Bold(true)
+	WriteModeStyle  = lipgloss.NewStyle().Padding(0, 1).Background(ErrorColor).Foreground(LightOnDarkBGColor).Bold(true)
+	StatusStyle     = lipgloss.NewStyle().Foreground(HighlightColor)
+	BannerStyle     = lipgloss.NewStyle().Foreground(ErrorColor)
 )
 
 // Resource styles
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/just-oblivious/swimpeek/internal/graph"
+	"github.com/just-oblivious/swimpeek/internal/lanedump"
+	"github.com/just-oblivious/swimpeek/internal/tui/app"
+	"github.com/just-oblivious/swimpeek/pkg/laneclient"
+)
+
+type tenantWriter struct {
+	client    laneclient.TenantClient
+	laneState *lanedump.LaneState
+}
+
+// NewTenantWriter creates a writer that toggles workflows and orchestration tasks in the tenant the dump was taken from.
+func NewTenantWriter(client laneclient.TenantClient, laneState *lanedump.LaneState) app.Writer {
+	return &tenantWriter{
+		client:    client,
+		laneState: laneState,
+	}
+}
+
+// taskIndex returns the index of the orchestration task represented by the node, or -1 if it doesn't exist.
+func (w *tenantWriter) taskIndex(node *graph.Node) int {
+	for idx, task := range w.laneState.OrchestrationTasks {
+		if task.Id == node.Meta.Id {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (w *tenantWriter) CanToggle(node *graph.Node) bool {
+	switch node.Meta.Type {
+	case graph.WorkflowNode:
+		_, exists := w.laneState.WorkflowsById[node.Meta.Id]
+		return exists
+	case graph.PlaybookButtonNode, graph.RecordEventNode:
+		return w.taskIndex(node) >= 0
+	}
+	return false
+}
+
+func (w *tenantWriter) IsEnabled(node *graph.Node) bool {
+	switch node.Meta.Type {
+	case graph.WorkflowNode:
+		return w.laneState.WorkflowsById[node.Meta.Id].Meta.Enabled
+	case graph.PlaybookButtonNode, graph.RecordEventNode:
+		if idx := w.taskIndex(node); idx >= 0 {
+			return !w.laneState.OrchestrationTasks[idx].Disabled
+		}
+	}
+	return false
+}
+
+func (w *tenantWriter) SetEnabled(ctx context.Context, node *graph.Node, enabled bool) error {
+	switch node.Meta.Type {
+	case graph.WorkflowNode:
+		return w.client.SetWorkflowEnabled(ctx, node.Meta.Id, enabled)
+	case graph.PlaybookButtonNode, graph.RecordEventNode:
+		return w.client.SetOrchestrationTaskDisabled(ctx, node.Meta.Id, !enabled)
+	}
+	return fmt.Errorf("cannot toggle %s %s", node.Meta.Type, node.Meta.Label)
+}
+
+func (w *tenantWriter) MarkEnabled(node *graph.Node, enabled bool) {
+	switch node.Meta.Type {
+	case graph.WorkflowNode:
+		if wf, exists := w.laneState.WorkflowsById[node.Meta.Id]; exists {
+			wf.Meta.Enabled = enabled
+			w.laneState.WorkflowsById[node.Meta.Id] = wf
+		}
+	case graph.PlaybookButtonNode, graph.RecordEventNode:
+		if idx := w.taskIndex(node); idx >= 0 {
+			w.laneState.OrchestrationTasks[idx].Disabled = !enabled
+		}
+	}
+}
@@ -2,6 +2,8 @@ package laneclient
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -24,15 +26,80 @@ type Application struct {
 }
 
 // ApplicationField describes a field in the application.
+// Attributes that are not modelled are kept in Extra, so fields survive a dump and load without losing information.
 type ApplicationField struct {
-	Type      string `json:"$type"`
-	FieldType string `json:"fieldType"`
-	Id        string `json:"id"`
-	Key       string `json:"key"`
-	InputType string `json:"inputType"`
-	Name      string `json:"name"`
-	Required  bool   `json:"required"`
-	ReadOnly  bool   `json:"readonly"`
+	Type             string          `json:"$type"`
+	FieldType        string          `json:"fieldType"`
+	Id               string          `json:"id"`
+	Key              string          `json:"key"`
+	InputType        string          `json:"inputType"`
+	Name             string          `json:"name"`
+	Required         bool            `json:"required"`
+	ReadOnly         bool            `json:"readonly"`
+	HelpText         string          `json:"helpText,omitempty"`
+	Unique           bool            `json:"unique,omitempty"`
+	Calculated       bool            `json:"calculated,omitempty"`
+	Formula          string          `json:"formula,omitempty"`
+	DefaultValueType string          `json:"defaultValueType,omitempty"`
+	DefaultValue     json.RawMessage `json:"defaultValue,omitempty"`
+	SelectionType    string          `json:"selectionType,omitempty"` // single or multi, for selection and reference fields.
+	ControlType      string          `json:"controlType,omitempty"`   // select, radio, or checkbox, for selection fields.
+	Values           []FieldValue    `json:"values,omitempty"`        // Values of a selection field.
+	TargetId         string          `json:"targetId,omitempty"`      // Application referenced by a reference field.
+	Columns          []string        `json:"columns,omitempty"`       // Fields of the target application shown by a reference field.
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// applicationField has the same fields as ApplicationField without its JSON methods.
+type applicationField ApplicationField
+
+func (f *ApplicationField) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalWithExtra(data, (*applicationField)(f))
+	f.Extra = extra
+	return err
+}
+
+func (f ApplicationField) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(applicationField(f), f.Extra)
+}
+
+// DefaultValues returns the default value of the field, selection fields have the selected values as default.
+func (f ApplicationField) DefaultValues() []string {
+	defaults := make([]string, 0)
+	for _, value := range f.Values {
+		if value.Selected {
+			defaults = append(defaults, value.Name)
+		}
+	}
+	if len(f.DefaultValue) > 0 && string(f.DefaultValue) != "null" {
+		defaults = append(defaults, string(f.DefaultValue))
+	}
+	return defaults
+}
+
+// FieldValue is a value that can be selected in a selection field.
+type FieldValue struct {
+	Type        string `json:"$type"`
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Selected    bool   `json:"selected"` // Selected by default.
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// fieldValue has the same fields as FieldValue without its JSON methods.
+type fieldValue FieldValue
+
+func (v *FieldValue) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalWithExtra(data, (*fieldValue)(v))
+	v.Extra = extra
+	return err
+}
+
+func (v FieldValue) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(fieldValue(v), v.Extra)
 }
 
 // GetApplications gets all applications in the tenant.
@@ -58,3 +125,42 @@ func (tc TenantClient) GetApplications(ctx context.Context) ([]Application, erro
 	}
 	return apps[0], nil
 }
+
+type ApplicationSummaries []ApplicationSummary
+
+// ApplicationSummary is the light-weight representation of an application, it is used to detect changes without fetching the fields.
+type ApplicationSummary struct {
+	Id           string    `json:"id"`
+	Name         string    `json:"name"`
+	Acronym      string    `json:"acronym"`
+	ModifiedDate time.Time `json:"modifiedDate"`
+	Version      int       `json:"version"`
+}
+
+// GetApplicationSummaries gets the light-weight representation of all applications in the tenant.
+func (tc TenantClient) GetApplicationSummaries(ctx context.Context) ([]ApplicationSummary, error) {
+	url, err := tc.urlForTenantEndpoint("", "app/light", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := getResource[ApplicationSummaries](ctx, tc.lc, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application summaries: %w", err)
+	}
+	return summaries, nil
+}
+
+// GetApplication gets a single application including its fields.
+func (tc TenantClient) GetApplication(ctx context.Context, appId string) (Application, error) {
+	url, err := tc.urlForTenantEndpoint("", "app/"+appId, 0)
+	if err != nil {
+		return Application{}, err
+	}
+
+	app, err := getResource[Application](ctx, tc.lc, url)
+	if err != nil {
+		return app, fmt.Errorf("failed to get application %s: %w", appId, err)
+	}
+	return app, nil
+}
@@ -0,0 +1,45 @@
+package laneclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// Asset holds the connection details (e.g. credentials, hostnames) used by connector actions.
+// Secret inputs are masked by the API and are not stored.
+type Asset struct {
+	Id   string `json:"id"`
+	Meta struct {
+		CreatedDate  time.Time `json:"createdDate"`
+		ModifiedDate time.Time `json:"modifiedDate"`
+	} `json:"meta"`
+	Asset struct {
+		Name        string `json:"name"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Type        string `json:"type"` // Asset type as declared by the connector manifest (e.g. http_basic_auth).
+		Connector   string `json:"connector"`
+	} `json:"asset"`
+}
+
+// StreamAssets iterates over all assets in the tenant.
+func (tc TenantClient) StreamAssets(ctx context.Context) iter.Seq2[Asset, error] {
+	url, err := tc.urlForTenantEndpoint("orchestration", "asset/rql", 1)
+	if err != nil {
+		return errSeq[Asset](err)
+	}
+
+	req, err := tc.lc.prepareRequest(ctx, http.MethodPost, url, nil, nil)
+	if err != nil {
+		return errSeq[Asset](err)
+	}
+
+	return decodeSeq[Asset](tc.lc.rqlItems(ctx, req))
+}
+
+// GetAssets gets all assets in the tenant.
+func (tc TenantClient) GetAssets(ctx context.Context) ([]Asset, error) {
+	return collect(tc.StreamAssets(ctx))
+}
@@ -0,0 +1,166 @@
+package laneclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthProvider adds credentials to API requests.
+type AuthProvider interface {
+	// Authenticate adds the credentials to the request, providers that need to sign in first can use the client to do so.
+	Authenticate(ctx context.Context, lc LaneClient, req *http.Request) error
+}
+
+// refreshableAuth is implemented by providers that can renew their credentials after the API rejected them.
+type refreshableAuth interface {
+	// invalidate discards the credentials used for the rejected request, they are renewed on the next call to Authenticate.
+	invalidate(req *http.Request)
+}
+
+// TokenAuth authenticates requests with a personal access token.
+type TokenAuth struct {
+	token string
+}
+
+// NewTokenAuth returns a provider that authenticates requests with the given personal access token.
+func NewTokenAuth(token string) TokenAuth {
+	return TokenAuth{token: token}
+}
+
+func (a TokenAuth) Authenticate(_ context.Context, _ LaneClient, req *http.Request) error {
+	req.Header.Set("Private-Token", a.token)
+	return nil
+}
+
+// tokenRefreshMargin is the time before expiry at which a login token is renewed.
+const tokenRefreshMargin = time.Minute
+
+// LoginAuth authenticates requests with a JWT obtained by signing in with a username and password.
+// The token is renewed when it is about to expire or when it is rejected by the API, a LoginAuth is safe for concurrent use.
+type LoginAuth struct {
+	username string
+	password string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time // Zero if the token does not expire.
+}
+
+// NewLoginAuth returns a provider that signs in with the given username and password.
+func NewLoginAuth(username string, password string) *LoginAuth {
+	return &LoginAuth{
+		username: username,
+		password: password,
+	}
+}
+
+func (a *LoginAuth) Authenticate(ctx context.Context, lc LaneClient, req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" || (!a.expires.IsZero() && time.Until(a.expires) < tokenRefreshMargin) {
+		if err := a.login(ctx, lc); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+func (a *LoginAuth) invalidate(req *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Concurrent requests may have been rejected with the same token, only the first one causes a new login.
+	if a.token != "" && req.Header.Get("Authorization") == "Bearer "+a.token {
+		a.token = ""
+	}
+}
+
+// loginResponse is the response of the login endpoint.
+type loginResponse struct {
+	Token string `json:"token"`
+}
+
+// login signs in and stores the token, the caller must hold the lock.
+func (a *LoginAuth) login(ctx context.Context, lc LaneClient) error {
+	loginURL, err := url.JoinPath(lc.baseURL, "api", "user", "login")
+	if err != nil {
+		return fmt.Errorf("failed to create login url: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"username": a.username,
+		"password": a.password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode login request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, loginURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create login request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	data, _, err := lc.doRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to sign in as %s: %w", a.username, err)
+	}
+
+	resp := loginResponse{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("failed to decode login response: %w", err)
+	}
+	if resp.Token == "" {
+		return fmt.Errorf("failed to sign in as %s: no token in login response", a.username)
+	}
+
+	a.token = resp.Token
+	a.expires = jwtExpiry(resp.Token)
+	lc.logger.Debug("Signed in", "username", a.username, "expires", a.expires)
+	return nil
+}
+
+// jwtExpiry returns the time from the exp claim of a JWT, the signature is not verified.
+// A zero time is returned if the token has no (readable) expiry, such tokens are only renewed when rejected.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	claims := struct {
+		Exp json.Number `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil || exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(exp), 0)
+}
+
+// WithAuth sets the provider used to authenticate requests, replacing the access token given to NewLaneClient.
+func WithAuth(auth AuthProvider) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.auth = auth
+	}
+}
@@ -2,7 +2,7 @@ package laneclient
 
 import (
 	"context"
-	"encoding/json"
+	"iter"
 	"net/http"
 )
 
@@ -50,22 +50,22 @@ type InputOutputProperty struct {
 	AdditionalProperties bool   `json:"additionalProperties"`
 }
 
-// GetConnectors gets all connectors available in the tenant.
-func (tc TenantClient) GetConnectors(ctx context.Context) ([]Connector, error) {
+// StreamConnectors iterates over all connectors available in the tenant.
+func (tc TenantClient) StreamConnectors(ctx context.Context) iter.Seq2[Connector, error] {
 	url, err := tc.urlForTenantEndpoint("orchestration", "connector/rql", 1)
 	if err != nil {
-		return nil, err
+		return errSeq[Connector](err)
 	}
 
 	req, err := tc.lc.prepareRequest(ctx, http.MethodPost, url, nil, nil)
 	if err != nil {
-		return nil, err
+		return errSeq[Connector](err)
 	}
 
-	var results []json.RawMessage
-	if err := tc.lc.rqlRequest(ctx, req, &results, "", "limit(100)"); err != nil {
-		return nil, err
-	}
+	return decodeSeq[Connector](tc.lc.rqlItems(ctx, req))
+}
 
-	return decodeItems[Connector](results...)
+// GetConnectors gets all connectors available in the tenant.
+func (tc TenantClient) GetConnectors(ctx context.Context) ([]Connector, error) {
+	return collect(tc.StreamConnectors(ctx))
 }
@@ -0,0 +1,126 @@
+package laneclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxErrorBodyLen is the maximum number of bytes of the response body kept in an APIError.
+const maxErrorBodyLen = 1024
+
+// requestIdHeaders are the response headers that may carry a request id, in order of preference.
+var requestIdHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Request-Id", "X-Amzn-Trace-Id"}
+
+// APIError is returned when the API responds with an unexpected status code.
+type APIError struct {
+	StatusCode int           // HTTP status code of the response.
+	Method     string        // HTTP method of the request.
+	URL        string        // URL of the request.
+	Body       string        // Response body, truncated to maxErrorBodyLen bytes.
+	RequestId  string        // Request id reported by the server, if any.
+	RetryAfter time.Duration // Retry-After hint for 429 and 503 responses.
+}
+
+// newAPIError creates an APIError from a response and its body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL.String(),
+		Body:       truncateBody(body),
+	}
+
+	for _, header := range requestIdHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			apiErr.RequestId = id
+			break
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("http %d for %s %s", e.StatusCode, e.Method, e.URL)
+	if detail := e.Message(); detail != "" {
+		msg += ": " + detail
+	}
+	if e.RequestId != "" {
+		msg += fmt.Sprintf(" (request id: %s)", e.RequestId)
+	}
+	return msg
+}
+
+// Message returns the error message reported by the server, falling back to the raw body excerpt.
+func (e *APIError) Message() string {
+	var body map[string]any
+	if err := json.Unmarshal([]byte(e.Body), &body); err == nil {
+		for _, key := range []string{"message", "Message", "error", "detail", "title", "errorMessage"} {
+			if msg, ok := body[key].(string); ok && msg != "" {
+				return msg
+			}
+		}
+	}
+	return strings.Join(strings.Fields(e.Body), " ")
+}
+
+// truncateBody returns the body as a string, truncated to maxErrorBodyLen bytes without splitting a UTF-8 sequence.
+func truncateBody(body []byte) string {
+	if len(body) <= maxErrorBodyLen {
+		return string(body)
+	}
+	cut := maxErrorBodyLen
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut]) + "…"
+}
+
+// AsAPIError returns the APIError wrapped in err, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// hasStatus returns true if err wraps an APIError with the given status code.
+func hasStatus(err error, statusCode int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == statusCode
+}
+
+// IsUnauthorized returns true if the request was rejected because the access token is missing, invalid or expired.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden returns true if the access token lacks the permission for the request.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound returns true if the requested resource or endpoint does not exist.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict returns true if an update was rejected because the resource was modified since it was read.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict) || hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// IsRateLimited returns true if the request was rejected by rate limiting.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
@@ -0,0 +1,70 @@
+package laneclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// unmarshalWithExtra decodes data into v (a pointer to a struct) and returns the members v has no field for.
+// Together with marshalWithExtra this allows models to keep attributes that are not modelled explicitly.
+func unmarshalWithExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	// encoding/json matches member names case-insensitively, so does this
+	known := make(map[string]bool)
+	for _, key := range jsonKeys(reflect.TypeOf(v).Elem()) {
+		known[strings.ToLower(key)] = true
+	}
+	for key := range members {
+		if known[strings.ToLower(key)] {
+			delete(members, key)
+		}
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members, nil
+}
+
+// marshalWithExtra encodes v and merges the extra members back in, modelled fields take precedence.
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]json.RawMessage, len(extra))
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, exists := members[key]; !exists {
+			members[key] = value
+		}
+	}
+	return json.Marshal(members)
+}
+
+// jsonKeys returns the JSON member names of the fields of a struct type.
+func jsonKeys(t reflect.Type) []string {
+	keys := make([]string, 0, t.NumField())
+	for idx := range t.NumField() {
+		field := t.Field(idx)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
@@ -0,0 +1,60 @@
+package laneclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SolutionRevisions is the revision history of a playbook or component.
+type SolutionRevisions []SolutionRevision
+
+// SolutionRevision describes a single saved revision of a playbook or component.
+type SolutionRevision struct {
+	Version      int         `json:"version"`
+	ModifiedDate time.Time   `json:"modifiedDate"`
+	ModifiedBy   IdentityRef `json:"modifiedBy"`
+	Comment      string      `json:"comment"`
+}
+
+// GetSolutionHistory gets the revision history of a playbook or component, newest revision first.
+func (tc TenantClient) GetSolutionHistory(ctx context.Context, kind SolutionKind, solutionId string) ([]SolutionRevision, error) {
+	url, err := tc.urlForTenantEndpoint("", fmt.Sprintf("solution-builder/%s/%s/history", kind, solutionId), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := getResource[SolutionRevisions](ctx, tc.lc, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of %s: %w", solutionId, err)
+	}
+	return revisions, nil
+}
+
+// GetSolutionRevision gets a playbook or component as it was at the given revision.
+func (tc TenantClient) GetSolutionRevision(ctx context.Context, kind SolutionKind, solutionId string, version int) (OrchestrationSolution, error) {
+	url, err := tc.urlForTenantEndpoint("", fmt.Sprintf("solution-builder/%s/%s/history/%d", kind, solutionId, version), 0)
+	if err != nil {
+		return OrchestrationSolution{}, err
+	}
+
+	solution, err := getResource[OrchestrationSolution](ctx, tc.lc, url)
+	if err != nil {
+		return solution, fmt.Errorf("failed to get revision %d of %s: %w", version, solutionId, err)
+	}
+	return solution, nil
+}
+
+// GetWorkflowRevision gets a workflow as it was at the given revision of the solution it belongs to.
+func (tc TenantClient) GetWorkflowRevision(ctx context.Context, workflowId string, version int) (Workflow, error) {
+	url, err := tc.urlForTenantEndpoint("orchestration", fmt.Sprintf("playbook/%s/history/%d", workflowId, version), 1)
+	if err != nil {
+		return Workflow{}, err
+	}
+
+	workflow, err := getResource[Workflow](ctx, tc.lc, url)
+	if err != nil {
+		return workflow, fmt.Errorf("failed to get revision %d of workflow %s: %w", version, workflowId, err)
+	}
+	return workflow, nil
+}
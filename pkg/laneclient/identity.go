@@ -0,0 +1,151 @@
+package laneclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AccessLevel is a bit mask describing the access a role grants on a resource.
+type AccessLevel int
+
+const (
+	AccessRead    AccessLevel = 1 << iota // View the resource (or records in an application).
+	AccessCreate                          // Create records.
+	AccessUpdate                          // Modify the resource or its records.
+	AccessDelete                          // Delete the resource or its records.
+	AccessExecute                         // Run a playbook (e.g. through a playbook button).
+)
+
+// Has returns true if all bits of the given level are set.
+func (a AccessLevel) Has(level AccessLevel) bool {
+	return a&level == level
+}
+
+func (a AccessLevel) String() string {
+	names := make([]string, 0, 5)
+	for _, level := range []struct {
+		bit  AccessLevel
+		name string
+	}{{AccessRead, "read"}, {AccessCreate, "create"}, {AccessUpdate, "update"}, {AccessDelete, "delete"}, {AccessExecute, "execute"}} {
+		if a.Has(level.bit) {
+			names = append(names, level.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// IdentityRef is a reference to a user, group, or role.
+type IdentityRef struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// User is an account user.
+type User struct {
+	Id          string        `json:"id"`
+	UserName    string        `json:"userName"`
+	DisplayName string        `json:"displayName"`
+	Email       string        `json:"email"`
+	Disabled    bool          `json:"disabled"`
+	IsAdmin     bool          `json:"isAdmin"`
+	LastLogin   time.Time     `json:"lastLogin"`
+	Roles       []IdentityRef `json:"roles"`
+	Groups      []IdentityRef `json:"groups"`
+}
+
+// Group is a collection of users that share roles.
+type Group struct {
+	Id          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Disabled    bool          `json:"disabled"`
+	Users       []IdentityRef `json:"users"`
+	Roles       []IdentityRef `json:"roles"`
+}
+
+// Role grants access to resources for the users and groups it is assigned to.
+type Role struct {
+	Id          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Users       []IdentityRef    `json:"users"`
+	Groups      []IdentityRef    `json:"groups"`
+	Permissions []RolePermission `json:"permissions"`
+}
+
+// RolePermission grants an access level on a single resource.
+type RolePermission struct {
+	Type       string      `json:"type"` // Resource type (e.g. Application, Solution).
+	ResourceId string      `json:"id"`
+	TenantId   string      `json:"tenantId"`
+	Name       string      `json:"name"`
+	Access     AccessLevel `json:"access"`
+}
+
+// AccountPage is a page of account-level resources.
+type AccountPage[T User | Group | Role] struct {
+	Items      []T `json:"viewModels"`
+	TotalCount int `json:"totalCount"`
+}
+
+// getAccountList requests all pages of an account-level list endpoint.
+func getAccountList[T User | Group | Role](ctx context.Context, lc LaneClient, endpoint string) ([]T, error) {
+	url, err := lc.urlForAccountEndpoint(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct %s URL: %w", endpoint, err)
+	}
+
+	pageSize := lc.pageSizeOr(defaultPageSize)
+	var results []T
+	for pageNum := 1; ; pageNum++ {
+		params := map[string]string{"pageNumber": strconv.Itoa(pageNum), "pageSize": strconv.Itoa(pageSize)}
+		req, err := lc.prepareRequest(ctx, http.MethodGet, url, params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare request for %s: %w", endpoint, err)
+		}
+
+		resp, err := lc.sendRequest(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to request %s: %w", endpoint, err)
+		}
+
+		var page AccountPage[T]
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", endpoint, err)
+		}
+		results = append(results, page.Items...)
+
+		// A short page is the last one, the total count is only trusted when the endpoint reports it
+		if len(page.Items) < pageSize || (page.TotalCount > 0 && len(results) >= page.TotalCount) {
+			return results, nil
+		}
+	}
+}
+
+// Account returns the account-level client for this tenant client.
+func (tc TenantClient) Account() LaneClient {
+	return tc.lc
+}
+
+// GetUsers gets all users in the account.
+func (lc LaneClient) GetUsers(ctx context.Context) ([]User, error) {
+	return getAccountList[User](ctx, lc, "users")
+}
+
+// GetGroups gets all groups in the account.
+func (lc LaneClient) GetGroups(ctx context.Context) ([]Group, error) {
+	return getAccountList[Group](ctx, lc, "groups")
+}
+
+// GetRoles gets all roles in the account.
+func (lc LaneClient) GetRoles(ctx context.Context) ([]Role, error) {
+	return getAccountList[Role](ctx, lc, "roles")
+}
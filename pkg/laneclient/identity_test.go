@@ -0,0 +1,54 @@
+package laneclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// usersServer serves the users endpoint in pages of the requested size, the total count is reported as given.
+func usersServer(t *testing.T, users int, totalCount string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/api/accounts/acc/users" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		pageNum, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+
+		items := make([]string, 0, pageSize)
+		for idx := (pageNum - 1) * pageSize; idx < min(pageNum*pageSize, users); idx++ {
+			items = append(items, fmt.Sprintf(`{"id":"u%d"}`, idx))
+		}
+		fmt.Fprintf(w, `{"viewModels":[%s]%s}`, strings.Join(items, ","), totalCount)
+	}))
+}
+
+func TestGetUsersPaging(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		totalCount string
+	}{
+		{"total count", `,"totalCount":5`},
+		{"zero total count", `,"totalCount":0`},
+		{"missing total count", ``},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := usersServer(t, 5, tc.totalCount)
+			defer srv.Close()
+
+			users, err := newTestClient(WithBaseURL(srv.URL), WithPageSize(2)).GetUsers(context.Background())
+			if err != nil {
+				t.Fatalf("GetUsers failed: %v", err)
+			}
+			if len(users) != 5 {
+				t.Errorf("got %d users, want 5", len(users))
+			}
+		})
+	}
+}
@@ -9,9 +9,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
-	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,11 +20,15 @@ import (
 )
 
 type LaneClient struct {
-	domain      string
+	baseURL     string
 	accountId   string
-	accessToken string
+	auth        AuthProvider
 	client      *http.Client
+	transport   transportConfig
 	logger      *log.Logger
+	retryPolicy RetryPolicy
+	retries     *retryCounter
+	pageSize    int
 }
 
 type TenantClient struct {
@@ -32,15 +36,44 @@ type TenantClient struct {
 	Tenant Tenant
 }
 
-// NewLaneClient returns a new API client.
-func NewLaneClient(domain string, accountId string, accessToken string, logger *log.Logger) LaneClient {
-	return LaneClient{
-		domain:      domain,
+// NewLaneClient returns a new API client for a cloud-hosted instance, options can be used to override the base URL, transport, and authentication.
+func NewLaneClient(domain string, accountId string, accessToken string, logger *log.Logger, options ...func(*LaneClient)) LaneClient {
+	lc := LaneClient{
+		baseURL:     "https://" + domain,
 		accountId:   accountId,
-		accessToken: accessToken,
-		client:      &http.Client{Timeout: 2 * time.Minute},
+		auth:        NewTokenAuth(accessToken),
 		logger:      logger,
+		retryPolicy: DefaultRetryPolicy(),
+		retries:     newRetryCounter(),
 	}
+	for _, option := range options {
+		option(&lc)
+	}
+	lc.client = &http.Client{
+		Timeout:   2 * time.Minute,
+		Transport: lc.transport.build(),
+	}
+	return lc
+}
+
+const (
+	defaultPageSize    = 50  // Default page size for paged endpoints.
+	defaultRQLPageSize = 100 // Default page size for RQL queries.
+)
+
+// WithPageSize sets the number of items requested per page for paged and RQL endpoints.
+func WithPageSize(size int) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.pageSize = size
+	}
+}
+
+// pageSizeOr returns the configured page size, or the given default if none is set.
+func (lc LaneClient) pageSizeOr(defaultSize int) int {
+	if lc.pageSize > 0 {
+		return lc.pageSize
+	}
+	return defaultSize
 }
 
 // NewTenantClient returns a new TenantClient for the specified tenant.
@@ -53,15 +86,15 @@ func NewTenantClient(lc LaneClient, tenant Tenant) TenantClient {
 
 // urlForAccountEndpoint returns the URL for an endpoint in the account context.
 func (lc LaneClient) urlForAccountEndpoint(endpoint string) (string, error) {
-	return url.JoinPath("https://", lc.domain, "tenant", "api", "accounts", lc.accountId, endpoint)
+	return url.JoinPath(lc.baseURL, "tenant", "api", "accounts", lc.accountId, endpoint)
 }
 
 // urlForTenantEndpoint return the url for an endpoint in the tenant context. Versioned endpoints can be used by specifying a nonzero value for apiVer.
 func (tc TenantClient) urlForTenantEndpoint(api string, endpoint string, apiVer uint8) (string, error) {
 	if apiVer > 0 {
-		return url.JoinPath("https://", tc.lc.domain, api, "api", "account", tc.lc.accountId, "tenant", tc.Tenant.Id, fmt.Sprintf("v%d", apiVer), endpoint)
+		return url.JoinPath(tc.lc.baseURL, api, "api", "account", tc.lc.accountId, "tenant", tc.Tenant.Id, fmt.Sprintf("v%d", apiVer), endpoint)
 	}
-	return url.JoinPath("https://", tc.lc.domain, "api", "account", tc.lc.accountId, "tenant", tc.Tenant.Id, endpoint)
+	return url.JoinPath(tc.lc.baseURL, "api", "account", tc.lc.accountId, "tenant", tc.Tenant.Id, endpoint)
 }
 
 // prepareRequest prepares a new http.request.
@@ -72,9 +105,13 @@ func (lc LaneClient) prepareRequest(ctx context.Context, method string, url stri
 	}
 
 	// Add request headers
-	req.Header.Add("Private-Token", lc.accessToken)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
+	if lc.auth != nil {
+		if err := lc.auth.Authenticate(ctx, lc, req); err != nil {
+			return nil, fmt.Errorf("failed to authenticate request: %w", err)
+		}
+	}
 
 	// Add query parameters
 	q := req.URL.Query()
@@ -86,124 +123,243 @@ func (lc LaneClient) prepareRequest(ctx context.Context, method string, url stri
 	return req, nil
 }
 
-// sendRequest submits a request and checks the response.
+// sendRequest submits a request and checks the response. Idempotent requests are retried on transient errors.
 func (lc LaneClient) sendRequest(req *http.Request) ([]byte, error) {
-	lc.logger.Debug("Request", "method", req.Method, "url", req.URL.String())
-
-	// Fire request
-	resp, err := lc.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
+	data, _, err := lc.sendRequestWithHeader(req)
+	return data, err
+}
 
-	// Check response
-	defer resp.Body.Close() //nolint:errcheck
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+// sendRequestWithHeader is sendRequest, it also returns the headers of the response.
+func (lc LaneClient) sendRequestWithHeader(req *http.Request) ([]byte, http.Header, error) {
+	retryable := isIdempotent(req)
+	endpoint := req.Method + " " + req.URL.Path
+	reauthenticated := false
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http %d for %s", resp.StatusCode, resp.Request.URL)
-	}
+	for attempt := 0; ; attempt++ {
+		data, header, err := lc.doRequest(req)
+		if err == nil {
+			return data, header, nil
+		}
 
-	return data, nil
-}
+		// Renew expired credentials once, this does not count as a retry
+		canResend := req.Body == nil || req.GetBody != nil
+		if refresher, ok := lc.auth.(refreshableAuth); ok && IsUnauthorized(err) && !reauthenticated && canResend {
+			reauthenticated = true
+			refresher.invalidate(req)
+			if authErr := lc.auth.Authenticate(req.Context(), lc, req); authErr != nil {
+				return nil, nil, fmt.Errorf("failed to renew credentials: %w", authErr)
+			}
+			if rewindErr := rewindBody(req); rewindErr != nil {
+				return nil, nil, rewindErr
+			}
+			lc.logger.Debug("Credentials renewed, resending request", "endpoint", endpoint)
+			attempt--
+			continue
+		}
 
-// pagedRequest incrementally requests data from a paged endpoint.
-func (lc LaneClient) pagedRequest(ctx context.Context, req *http.Request, items *[]json.RawMessage) error {
-	select {
-	case <-ctx.Done():
-		return nil
-	default:
-	}
+		if !retryable || attempt >= lc.retryPolicy.MaxRetries || !isRetryable(err) {
+			return nil, nil, err
+		}
 
-	q := req.URL.Query()
-	q.Set("size", "50")
+		// Rewind the request body before trying again
+		if rewindErr := rewindBody(req); rewindErr != nil {
+			return nil, nil, rewindErr
+		}
 
-	// Increment page number in query string
-	if page := q.Get("page"); page != "" {
-		pageNum, err := strconv.ParseUint(page, 10, 32)
-		if err != nil {
-			return fmt.Errorf("failed to parse page number: %w", err)
+		var retryAfter time.Duration
+		if apiErr, ok := AsAPIError(err); ok {
+			retryAfter = apiErr.RetryAfter
 		}
-		if pageNum > 50 {
-			return fmt.Errorf("recursion limit reached")
+		delay := lc.retryPolicy.backoff(attempt, retryAfter)
+		lc.retries.add(endpoint)
+		lc.logger.Warn("Request failed, retrying", "endpoint", endpoint, "attempt", attempt+1, "delay", delay, "error", err)
+
+		select {
+		case <-req.Context().Done():
+			return nil, nil, req.Context().Err()
+		case <-time.After(delay):
 		}
-		q.Set("page", strconv.FormatUint(pageNum+1, 10))
-	} else {
-		q.Add("page", "1")
 	}
-	req.URL.RawQuery = q.Encode()
+}
 
-	// Fire request
-	resp, err := lc.sendRequest(req)
-	if err != nil {
-		return fmt.Errorf("failed to send paged request: %w", err)
+// rewindBody resets the body of a request so it can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
 	}
-
-	// Decode page response
-	page, err := decodeItems[ItemPage](resp)
+	body, err := req.GetBody()
 	if err != nil {
-		return fmt.Errorf("failed to decode paged response: %w", err)
+		return fmt.Errorf("failed to rewind request body: %w", err)
 	}
-	*items = slices.Concat(*items, page[0].Items)
-
-	// if the number of items returned is equal to the page size, assume there's another page and request it.
-	if len(page[0].Items) == 50 {
-		return lc.pagedRequest(ctx, req, items)
-	}
-
+	req.Body = body
 	return nil
 }
 
-// rqlRequest queries an RQL endpoint.
-func (lc LaneClient) rqlRequest(ctx context.Context, req *http.Request, results *[]json.RawMessage, cursor string, queryParts ...string) error {
-	select {
-	case <-ctx.Done():
-		return nil
-	default:
-	}
+// doRequest performs a single round-trip and checks the response, the body and headers of the response are returned.
+func (lc LaneClient) doRequest(req *http.Request) ([]byte, http.Header, error) {
+	lc.logger.Debug("Request", "method", req.Method, "url", req.URL.String())
 
-	// Format RQL query string.
-	rqlString := fmt.Sprintf("and(%s)", strings.Join(queryParts, ","))
-	if cursor != "" {
-		rqlString = fmt.Sprintf("and(%s)", strings.Join([]string{rqlString, fmt.Sprintf("after(%s)", cursor)}, ","))
+	// Fire request
+	resp, err := lc.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
-	// Format JSON
-	rqlJSON, err := json.Marshal(struct {
-		RQL string `json:"rql"`
-	}{rqlString})
+	// Check response
+	defer resp.Body.Close() //nolint:errcheck
+	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to marshal RQL query: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Set request body
-	req.Body = io.NopCloser(bytes.NewReader(rqlJSON))
-	req.ContentLength = int64(len(rqlJSON))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, newAPIError(resp, data)
+	}
 
-	// Fire request
-	resp, err := lc.sendRequest(req)
-	if err != nil {
-		return fmt.Errorf("failed to send RQL request: %w", err)
+	return data, resp.Header, nil
+}
+
+// pagedItems iterates over the items of a paged endpoint, pages are requested on demand.
+func (lc LaneClient) pagedItems(ctx context.Context, req *http.Request) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		pageSize := lc.pageSizeOr(defaultPageSize)
+		markIdempotent(req)
+
+		for pageNum := 1; ; pageNum++ {
+			if err := ctx.Err(); err != nil {
+				yield(nil, fmt.Errorf("paged request cancelled at page %d: %w", pageNum, err))
+				return
+			}
+
+			// Set page number and size in query string
+			q := req.URL.Query()
+			q.Set("size", strconv.Itoa(pageSize))
+			q.Set("page", strconv.Itoa(pageNum))
+			req.URL.RawQuery = q.Encode()
+
+			// Fire request
+			resp, err := lc.sendRequest(req)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to send paged request: %w", err))
+				return
+			}
+
+			// Decode page response
+			page, err := decodeItem[ItemPage](resp)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to decode paged response: %w", err))
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// If fewer items than the page size are returned, this was the last page.
+			if len(page.Items) < pageSize {
+				return
+			}
+		}
 	}
+}
 
-	// Decode RQL page response
-	page, err := decodeItems[RQLResult](resp)
-	if err != nil {
-		return fmt.Errorf("failed to decode RQL response: %w", err)
+// rqlItems iterates over the results of an RQL query, pages are requested on demand by following the page cursor.
+func (lc LaneClient) rqlItems(ctx context.Context, req *http.Request, queryParts ...string) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		queryParts := append([]string{fmt.Sprintf("limit(%d)", lc.pageSizeOr(defaultRQLPageSize))}, queryParts...)
+		cursor := ""
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, fmt.Errorf("RQL request cancelled: %w", err))
+				return
+			}
+
+			// Format RQL query string.
+			rqlString := fmt.Sprintf("and(%s)", strings.Join(queryParts, ","))
+			if cursor != "" {
+				rqlString = fmt.Sprintf("and(%s)", strings.Join([]string{rqlString, fmt.Sprintf("after(%s)", cursor)}, ","))
+			}
+
+			// Format JSON
+			rqlJSON, err := json.Marshal(struct {
+				RQL string `json:"rql"`
+			}{rqlString})
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to marshal RQL query: %w", err))
+				return
+			}
+
+			// Set request body, RQL queries don't modify anything and can safely be retried
+			req.Body = io.NopCloser(bytes.NewReader(rqlJSON))
+			req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(rqlJSON)), nil }
+			req.ContentLength = int64(len(rqlJSON))
+			markIdempotent(req)
+
+			// Fire request
+			resp, err := lc.sendRequest(req)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to send RQL request: %w", err))
+				return
+			}
+
+			// Decode RQL page response
+			page, err := decodeItem[RQLResult](resp)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to decode RQL response: %w", err))
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item.Item, nil) {
+					return
+				}
+			}
+
+			// If there's a page cursor, request the next page
+			if !page.Meta.HasNextPage {
+				return
+			}
+			cursor = page.Meta.PageCursor.Next
+		}
 	}
-	for _, item := range page[0].Items {
-		*results = append(*results, item.Item)
+}
+
+// decodeSeq lazily decodes the raw JSON items of an iterator into a ResponseModel.
+func decodeSeq[T ResponseModel](items iter.Seq2[json.RawMessage, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range items {
+			if err != nil {
+				var empty T
+				yield(empty, err)
+				return
+			}
+			res, err := decodeItem[T](item)
+			if !yield(res, err) || err != nil {
+				return
+			}
+		}
 	}
+}
 
-	// If there's a page cursor, request the next page
-	if page[0].Meta.HasNextPage {
-		return lc.rqlRequest(ctx, req, results, page[0].Meta.PageCursor.Next, queryParts...)
+// errSeq returns an iterator that yields a single error.
+func errSeq[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var empty T
+		yield(empty, err)
 	}
+}
 
-	return nil
+// collect gathers all items of an iterator into a slice, stopping at the first error.
+func collect[T any](items iter.Seq2[T, error]) ([]T, error) {
+	var results []T
+	for item, err := range items {
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return results, nil
 }
 
 // decodeItems decodes a list of raw JSON items into a ResponseModel.
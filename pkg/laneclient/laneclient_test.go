@@ -0,0 +1,213 @@
+package laneclient
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+const tenantsPath = "/tenant/api/accounts/acc/tenants"
+
+// tenantsHandler responds to the tenants endpoint with a single tenant.
+func tenantsHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != tenantsPath {
+			t.Errorf("unexpected path %s, want %s", r.URL.Path, tenantsPath)
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, `{"viewModels":[{"name":"Test","id":"t1"}],"totalCount":1}`) //nolint:errcheck
+	}
+}
+
+// newTestClient returns a client for the account "acc" that discards its log output and doesn't retry failed requests.
+func newTestClient(options ...func(*LaneClient)) LaneClient {
+	options = append([]func(*LaneClient){WithRetryPolicy(RetryPolicy{})}, options...)
+	return NewLaneClient("swimlane.invalid", "acc", "secret", log.New(io.Discard), options...)
+}
+
+// assertTenants checks that the tenants request succeeded and returned the test tenant.
+func assertTenants(t *testing.T, lc LaneClient) {
+	t.Helper()
+	tenants, err := lc.GetTenants(context.Background())
+	if err != nil {
+		t.Fatalf("GetTenants failed: %v", err)
+	}
+	if len(tenants.Tenants) != 1 || tenants.Tenants[0].Id != "t1" {
+		t.Fatalf("unexpected tenants: %+v", tenants)
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	var token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Private-Token")
+		tenantsHandler(t)(w, r)
+	}))
+	defer srv.Close()
+
+	// A trailing slash must not lead to a double slash in the request path
+	assertTenants(t, newTestClient(WithBaseURL(srv.URL+"/")))
+	if token != "secret" {
+		t.Errorf("Private-Token header = %q, want %q", token, "secret")
+	}
+}
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithRoundTripper(t *testing.T) {
+	rec := httptest.NewRecorder()
+	var calls int
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if req.URL.Host != "swimlane.invalid" {
+			t.Errorf("request host = %s, want swimlane.invalid", req.URL.Host)
+		}
+		tenantsHandler(t)(rec, req)
+		resp := rec.Result()
+		resp.Request = req
+		return resp, nil
+	})
+
+	// The custom transport takes precedence over the proxy setting
+	proxy, _ := url.Parse("http://proxy.invalid:3128")
+	assertTenants(t, newTestClient(WithRoundTripper(rt), WithProxy(proxy)))
+	if calls != 1 {
+		t.Errorf("round tripper called %d times, want 1", calls)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests to a proxy carry the absolute URL of the target
+		proxiedHost = r.URL.Host
+		tenantsHandler(t)(w, r)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTenants(t, newTestClient(WithBaseURL("http://swimlane.invalid"), WithProxy(proxyURL)))
+	if proxiedHost != "swimlane.invalid" {
+		t.Errorf("proxied host = %q, want swimlane.invalid", proxiedHost)
+	}
+}
+
+func TestTLSOptions(t *testing.T) {
+	srv := httptest.NewTLSServer(tenantsHandler(t))
+	defer srv.Close()
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		_, err := newTestClient(WithBaseURL(srv.URL)).GetTenants(context.Background())
+		var certErr x509.UnknownAuthorityError
+		if err == nil || !strings.Contains(err.Error(), certErr.Error()) {
+			t.Fatalf("expected an unknown authority error, got %v", err)
+		}
+	})
+
+	t.Run("root CAs", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+		assertTenants(t, newTestClient(WithBaseURL(srv.URL), WithRootCAs(pool)))
+	})
+
+	t.Run("CA bundle", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+		if err := os.WriteFile(bundle, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		pool, err := LoadCABundle(bundle)
+		if err != nil {
+			t.Fatalf("LoadCABundle failed: %v", err)
+		}
+		assertTenants(t, newTestClient(WithBaseURL(srv.URL), WithRootCAs(pool)))
+	})
+
+	t.Run("invalid CA bundle", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		if err := os.WriteFile(bundle, []byte("not a certificate"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadCABundle(bundle); err == nil {
+			t.Fatal("expected an error for a bundle without certificates")
+		}
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		assertTenants(t, newTestClient(WithBaseURL(srv.URL), WithInsecureSkipVerify(true)))
+	})
+}
+
+func TestReauthenticateOnUnauthorized(t *testing.T) {
+	var logins, requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/user/login" {
+			// The first token is rejected as if it expired on the server
+			if logins.Add(1) == 1 {
+				io.WriteString(w, `{"token":"expired"}`) //nolint:errcheck
+			} else {
+				io.WriteString(w, `{"token":"fresh"}`) //nolint:errcheck
+			}
+			return
+		}
+
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			http.Error(w, `{"message":"token expired"}`, http.StatusUnauthorized)
+			return
+		}
+		tenantsHandler(t)(w, r)
+	}))
+	defer srv.Close()
+
+	// The test client doesn't retry, so the renewal must not depend on the retry policy
+	lc := newTestClient(WithBaseURL(srv.URL), WithAuth(NewLoginAuth("user", "pass")))
+	assertTenants(t, lc)
+	if logins.Load() != 2 {
+		t.Errorf("logins = %d, want 2", logins.Load())
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2", requests.Load())
+	}
+	if counts := lc.RetryCounts(); len(counts) != 0 {
+		t.Errorf("renewal counted as retry: %v", counts)
+	}
+}
+
+func TestUnauthorizedWithTokenAuth(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, `{"message":"invalid token"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	// Access tokens can't be renewed, the request fails without being resent
+	_, err := newTestClient(WithBaseURL(srv.URL)).GetTenants(context.Background())
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("requests = %d, want 1", requests.Load())
+	}
+}
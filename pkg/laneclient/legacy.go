@@ -0,0 +1,86 @@
+package laneclient
+
+import (
+	"context"
+	"time"
+)
+
+type LegacyTasks []LegacyTask
+
+// LegacyTask is an integration task from before Turbine, it runs a script or plugin action against records of an application.
+type LegacyTask struct {
+	Id            string              `json:"id"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	Disabled      bool                `json:"disabled"`
+	ApplicationId string              `json:"applicationId"` // Application the task reads its inputs from.
+	Action        LegacyTaskAction    `json:"action"`
+	InputMapping  []LegacyTaskMapping `json:"inputMapping"`
+	Outputs       []LegacyTaskOutput  `json:"outputs"`
+	CreatedDate   time.Time           `json:"createdDate"`
+	ModifiedDate  time.Time           `json:"modifiedDate"`
+}
+
+// LegacyTaskAction describes what a legacy task runs.
+type LegacyTaskAction struct {
+	Type       string         `json:"type"` // python, powershell, or plugin.
+	Descriptor map[string]any `json:"descriptor,omitempty"`
+	AssetIds   []string       `json:"assetIds"`
+}
+
+// LegacyTaskMapping maps a task input or output to a record field.
+type LegacyTaskMapping struct {
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Value string `json:"value"` // Field id for record mappings.
+}
+
+// LegacyTaskOutput describes how the results of a legacy task are written back to records.
+type LegacyTaskOutput struct {
+	Type          string              `json:"type"`          // update, create, or email.
+	ApplicationId string              `json:"applicationId"` // Application the output writes to, empty for the triggering application.
+	Mappings      []LegacyTaskMapping `json:"mappings"`
+}
+
+// WrittenFieldIds returns the ids of the fields the task writes to in the given application.
+func (t LegacyTask) WrittenFieldIds(appId string) map[string]bool {
+	fieldIds := make(map[string]bool)
+	for _, output := range t.Outputs {
+		outputAppId := output.ApplicationId
+		if outputAppId == "" {
+			outputAppId = t.ApplicationId
+		}
+		if outputAppId != appId {
+			continue
+		}
+		for _, mapping := range output.Mappings {
+			if mapping.Value != "" {
+				fieldIds[mapping.Value] = true
+			}
+		}
+	}
+	return fieldIds
+}
+
+type LegacyTriggers []LegacyTrigger
+
+// LegacyTrigger starts legacy tasks when records change, when an integration button is pressed, or on a schedule.
+type LegacyTrigger struct {
+	Id            string   `json:"id"`
+	Name          string   `json:"name"`
+	Disabled      bool     `json:"disabled"`
+	Type          string   `json:"type"`          // record, button, or schedule.
+	ApplicationId string   `json:"applicationId"` // Application whose records or button start the trigger, empty for schedules.
+	FieldId       string   `json:"fieldId"`       // Integration button field, for button triggers.
+	TaskIds       []string `json:"taskIds"`
+}
+
+// GetLegacyTasks gets all legacy integration tasks in the tenant.
+func (tc TenantClient) GetLegacyTasks(ctx context.Context) ([]LegacyTask, error) {
+	return getTenantList[LegacyTasks](ctx, tc, "task")
+}
+
+// GetLegacyTriggers gets all legacy triggers in the tenant.
+func (tc TenantClient) GetLegacyTriggers(ctx context.Context) ([]LegacyTrigger, error) {
+	return getTenantList[LegacyTriggers](ctx, tc, "trigger")
+}
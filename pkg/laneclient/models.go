@@ -11,10 +11,22 @@ type ResponseModel interface {
 		OrchestrationSolution |
 		Workflow |
 		Applications |
+		Application |
+		ApplicationSummaries |
 		Connector |
 		OrchestrationTasks |
+		OrchestrationTask |
+		SolutionRevisions |
 		Sensor |
-		TenantResponse
+		Asset |
+		PlaybookRun |
+		Workspaces |
+		Dashboards |
+		Reports |
+		LegacyTasks |
+		LegacyTriggers |
+		TenantResponse |
+		TenantSettings
 }
 
 // ItemPage is a common structure for paginated responses.
@@ -2,8 +2,8 @@ package laneclient
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -53,34 +53,49 @@ type TaskTrigger struct {
 	HasRecord                   bool `json:"hasRecord"`
 }
 
-// getOrchestrationSolutions gets orchestration solutions from the solution builder.
-func (tc TenantClient) getOrchestrationSolutions(ctx context.Context, resourceType string) ([]OrchestrationSolution, error) {
+// streamOrchestrationSolutions iterates over orchestration solutions from the solution builder.
+func (tc TenantClient) streamOrchestrationSolutions(ctx context.Context, resourceType string) iter.Seq2[OrchestrationSolution, error] {
 	url, err := tc.urlForTenantEndpoint("", fmt.Sprintf("solution-builder/%s/filter", resourceType), 0)
 	if err != nil {
-		return nil, err
+		return errSeq[OrchestrationSolution](err)
 	}
 
 	req, err := tc.lc.prepareRequest(ctx, http.MethodPost, url, nil, nil)
 	if err != nil {
-		return nil, err
+		return errSeq[OrchestrationSolution](err)
 	}
 
-	var items []json.RawMessage
-	if err := tc.lc.pagedRequest(ctx, req, &items); err != nil {
-		return nil, fmt.Errorf("failed to get %s: %w", resourceType, err)
+	return func(yield func(OrchestrationSolution, error) bool) {
+		for solution, err := range decodeSeq[OrchestrationSolution](tc.lc.pagedItems(ctx, req)) {
+			if err != nil {
+				yield(solution, fmt.Errorf("failed to get %s: %w", resourceType, err))
+				return
+			}
+			if !yield(solution, nil) {
+				return
+			}
+		}
 	}
+}
+
+// StreamPlaybooks iterates over all playbooks (solutions) in the tenant.
+func (tc TenantClient) StreamPlaybooks(ctx context.Context) iter.Seq2[OrchestrationSolution, error] {
+	return tc.streamOrchestrationSolutions(ctx, "solutions")
+}
 
-	return decodeItems[OrchestrationSolution](items...)
+// StreamComponents iterates over all components in the tenant.
+func (tc TenantClient) StreamComponents(ctx context.Context) iter.Seq2[OrchestrationSolution, error] {
+	return tc.streamOrchestrationSolutions(ctx, "components")
 }
 
 // GetSolutions gets all playbooks (solutions) in the tenant.
 func (tc TenantClient) GetPlaybooks(ctx context.Context) ([]OrchestrationSolution, error) {
-	return tc.getOrchestrationSolutions(ctx, "solutions")
+	return collect(tc.StreamPlaybooks(ctx))
 }
 
 // GetComponents gets all components in the tenant.
 func (tc TenantClient) GetComponents(ctx context.Context) ([]OrchestrationSolution, error) {
-	return tc.getOrchestrationSolutions(ctx, "components")
+	return collect(tc.StreamComponents(ctx))
 }
 
 // GetOrchestrationTasks returns all orchestration tasks in the tenant.
@@ -106,3 +121,34 @@ func (tc TenantClient) GetOrchestrationTasks(ctx context.Context) ([]Orchestrati
 	}
 	return tasks[0], nil
 }
+
+// GetOrchestrationTask gets a single orchestration task.
+func (tc TenantClient) GetOrchestrationTask(ctx context.Context, taskId string) (OrchestrationTask, error) {
+	url, err := tc.urlForTenantEndpoint("", "orchestrationtask/"+taskId, 0)
+	if err != nil {
+		return OrchestrationTask{}, err
+	}
+
+	task, err := getResource[OrchestrationTask](ctx, tc.lc, url)
+	if err != nil {
+		return task, fmt.Errorf("failed to get orchestration task %s: %w", taskId, err)
+	}
+	return task, nil
+}
+
+// SetOrchestrationTaskDisabled disables or enables an orchestration task.
+func (tc TenantClient) SetOrchestrationTaskDisabled(ctx context.Context, taskId string, disabled bool) error {
+	url, err := tc.urlForTenantEndpoint("", "orchestrationtask/"+taskId, 0)
+	if err != nil {
+		return err
+	}
+
+	err = tc.lc.updateResource(ctx, url, func(doc map[string]any) error {
+		doc["disabled"] = disabled
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set disabled state of orchestration task %s: %w", taskId, err)
+	}
+	return nil
+}
@@ -2,7 +2,8 @@ package laneclient
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -67,22 +68,57 @@ type ActionLoop struct {
 	Parallel bool   `json:"parallel"`
 }
 
-// GetPlaybookWorkflows gets all playbook workflows in the tenant.
-func (tc TenantClient) GetPlaybookWorkflows(ctx context.Context) ([]Workflow, error) {
+// StreamPlaybookWorkflows iterates over all playbook workflows in the tenant.
+func (tc TenantClient) StreamPlaybookWorkflows(ctx context.Context) iter.Seq2[Workflow, error] {
 	url, err := tc.urlForTenantEndpoint("orchestration", "playbook/rql", 1)
 	if err != nil {
-		return nil, err
+		return errSeq[Workflow](err)
 	}
 
 	req, err := tc.lc.prepareRequest(ctx, http.MethodPost, url, nil, nil)
 	if err != nil {
-		return nil, err
+		return errSeq[Workflow](err)
+	}
+
+	return decodeSeq[Workflow](tc.lc.rqlItems(ctx, req))
+}
+
+// GetPlaybookWorkflows gets all playbook workflows in the tenant.
+func (tc TenantClient) GetPlaybookWorkflows(ctx context.Context) ([]Workflow, error) {
+	return collect(tc.StreamPlaybookWorkflows(ctx))
+}
+
+// GetWorkflow gets a single playbook workflow.
+func (tc TenantClient) GetWorkflow(ctx context.Context, workflowId string) (Workflow, error) {
+	url, err := tc.urlForTenantEndpoint("orchestration", "playbook/"+workflowId, 1)
+	if err != nil {
+		return Workflow{}, err
 	}
 
-	var results []json.RawMessage
-	if err := tc.lc.rqlRequest(ctx, req, &results, "", "limit(100)"); err != nil {
-		return nil, err
+	workflow, err := getResource[Workflow](ctx, tc.lc, url)
+	if err != nil {
+		return workflow, fmt.Errorf("failed to get workflow %s: %w", workflowId, err)
 	}
+	return workflow, nil
+}
 
-	return decodeItems[Workflow](results...)
+// SetWorkflowEnabled enables or disables a playbook workflow.
+func (tc TenantClient) SetWorkflowEnabled(ctx context.Context, workflowId string, enabled bool) error {
+	url, err := tc.urlForTenantEndpoint("orchestration", "playbook/"+workflowId, 1)
+	if err != nil {
+		return err
+	}
+
+	err = tc.lc.updateResource(ctx, url, func(doc map[string]any) error {
+		meta, ok := doc["meta"].(map[string]any)
+		if !ok {
+			return fmt.Errorf("workflow %s has no meta section", workflowId)
+		}
+		meta["enabled"] = enabled
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set enabled state of workflow %s: %w", workflowId, err)
+	}
+	return nil
 }
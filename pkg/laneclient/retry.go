@@ -0,0 +1,144 @@
+package laneclient
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	MaxRetries int           // Maximum number of retries per request, zero disables retries.
+	BaseDelay  time.Duration // Delay before the first retry, doubled for every subsequent retry.
+	MaxDelay   time.Duration // Upper bound for the backoff delay, including delays requested by the server with Retry-After.
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is specified.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 5,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// backoff returns the delay before the given retry attempt (zero-based), a Retry-After hint from the server takes precedence.
+// The hint is capped at MaxDelay so a misbehaving server can't stall the client.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if p.MaxDelay > 0 {
+			return min(retryAfter, p.MaxDelay)
+		}
+		return retryAfter
+	}
+
+	delay := p.MaxDelay
+	if attempt < 32 {
+		delay = min(p.BaseDelay<<attempt, p.MaxDelay)
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Apply jitter to the upper half of the delay so concurrent fetches don't retry in lockstep.
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// retryCounter keeps track of the number of retries per endpoint, it is shared between copies of a LaneClient.
+type retryCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newRetryCounter() *retryCounter {
+	return &retryCounter{
+		counts: make(map[string]int),
+	}
+}
+
+// add increments the retry count for an endpoint.
+func (rc *retryCounter) add(endpoint string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.counts[endpoint]++
+}
+
+// snapshot returns a copy of the retry counts.
+func (rc *retryCounter) snapshot() map[string]int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	counts := make(map[string]int, len(rc.counts))
+	for endpoint, count := range rc.counts {
+		counts[endpoint] = count
+	}
+	return counts
+}
+
+// markIdempotent flags a request as safe to retry, this follows the net/http convention of setting a nil Idempotency-Key header (which is not sent).
+func markIdempotent(req *http.Request) {
+	req.Header["Idempotency-Key"] = nil
+}
+
+// isIdempotent returns true if the request can safely be repeated.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	_, marked := req.Header["Idempotency-Key"]
+	return marked
+}
+
+// isRetryable returns true if the error is transient and the request may succeed when repeated.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if apiErr, ok := AsAPIError(err); ok {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	// Network errors (connection resets, timeouts, etc.)
+	return true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which may either be a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests.
+func WithRetryPolicy(policy RetryPolicy) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.retryPolicy = policy
+	}
+}
+
+// RetryCounts returns the number of retries that were needed per endpoint.
+func (lc LaneClient) RetryCounts() map[string]int {
+	return lc.retries.snapshot()
+}
+
+// RetryCounts returns the number of retries that were needed per endpoint.
+func (tc TenantClient) RetryCounts() map[string]int {
+	return tc.lc.RetryCounts()
+}
@@ -0,0 +1,79 @@
+package laneclient
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// PlaybookRun is a single execution of a workflow.
+type PlaybookRun struct {
+	Id         string      `json:"id"`
+	PlaybookId string      `json:"playbookId"` // The workflow that was executed.
+	Status     string      `json:"status"`
+	StartTime  time.Time   `json:"startTime"`
+	EndTime    time.Time   `json:"endTime"`
+	Actions    []ActionRun `json:"actions"`
+}
+
+// ActionRun is the execution of a single action within a PlaybookRun.
+type ActionRun struct {
+	ActionId  string    `json:"actionId"`
+	Status    string    `json:"status"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// runFailed returns true if the run status indicates a failure.
+func runFailed(status string) bool {
+	switch status {
+	case "failed", "error", "timedOut", "terminated":
+		return true
+	}
+	return false
+}
+
+// runDuration returns the duration of a run, or zero if the run has not completed.
+func runDuration(start time.Time, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// Failed returns true if the workflow run failed.
+func (r PlaybookRun) Failed() bool {
+	return runFailed(r.Status)
+}
+
+// Duration returns the duration of the workflow run, or zero if it has not completed.
+func (r PlaybookRun) Duration() time.Duration {
+	return runDuration(r.StartTime, r.EndTime)
+}
+
+// Failed returns true if the action run failed.
+func (r ActionRun) Failed() bool {
+	return runFailed(r.Status)
+}
+
+// Duration returns the duration of the action run, or zero if it has not completed.
+func (r ActionRun) Duration() time.Duration {
+	return runDuration(r.StartTime, r.EndTime)
+}
+
+// StreamPlaybookRuns iterates over all workflow runs that started after the given time.
+func (tc TenantClient) StreamPlaybookRuns(ctx context.Context, since time.Time) iter.Seq2[PlaybookRun, error] {
+	url, err := tc.urlForTenantEndpoint("orchestration", "playbook-run/rql", 1)
+	if err != nil {
+		return errSeq[PlaybookRun](err)
+	}
+
+	req, err := tc.lc.prepareRequest(ctx, http.MethodPost, url, nil, nil)
+	if err != nil {
+		return errSeq[PlaybookRun](err)
+	}
+
+	return decodeSeq[PlaybookRun](tc.lc.rqlItems(ctx, req, fmt.Sprintf("gte(startTime,%s)", since.UTC().Format(time.RFC3339))))
+}
@@ -3,7 +3,10 @@ package laneclient
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"net/http"
+	"slices"
+	"strings"
 )
 
 // Sensor represents a webhook or flow event listener.
@@ -16,30 +19,118 @@ type Sensor struct {
 		EmittedByPlaybooks []string `json:"emittedByPlaybooks"`
 		TriggeredPlaybooks []string `json:"triggeredPlaybooks"`
 	} `json:"meta"`
-	Sensor struct {
-		Description string `json:"description"`
-		Name        string `json:"name"`
-		Type        string `json:"type"`
-		Title       string `json:"title"`
+	Sensor SensorConfig `json:"sensor"`
+}
+
+// SensorConfig holds the configuration of a sensor, attributes that are not modelled are kept in Extra.
+type SensorConfig struct {
+	Description string         `json:"description"`
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	Title       string         `json:"title"`
+	Path        string         `json:"path,omitempty"`   // Endpoint path of a webhook.
+	Url         string         `json:"url,omitempty"`    // Full endpoint URL of a webhook, when provided by the tenant.
+	Auth        SensorAuth     `json:"auth"`             // Authentication required to call a webhook.
+	Schema      map[string]any `json:"schema,omitempty"` // JSON schema of the expected payload.
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// SensorAuth describes how callers of a webhook authenticate.
+type SensorAuth struct {
+	Type    string `json:"type"`              // none, basic, token, or hmac.
+	Header  string `json:"header,omitempty"`  // Header carrying the token or signature.
+	AssetId string `json:"assetId,omitempty"` // Asset holding the credentials or secret.
+}
+
+// sensorConfig has the same fields as SensorConfig without its JSON methods.
+type sensorConfig SensorConfig
+
+func (c *SensorConfig) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalWithExtra(data, (*sensorConfig)(c))
+	c.Extra = extra
+	return err
+}
+
+func (c SensorConfig) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(sensorConfig(c), c.Extra)
+}
+
+// Endpoint returns the URL of a webhook if the tenant provides it, otherwise its path.
+func (c SensorConfig) Endpoint() string {
+	if c.Url != "" {
+		return c.Url
 	}
+	return c.Path
 }
 
-// GetSensors gets all 'sensors' in the tenant.
-func (tc TenantClient) GetSensors(ctx context.Context) ([]Sensor, error) {
+// IsAuthenticated returns true if callers of the webhook need to authenticate.
+func (c SensorConfig) IsAuthenticated() bool {
+	return c.Auth.Type != "" && c.Auth.Type != "none"
+}
+
+// SchemaField is a property of the expected payload.
+type SchemaField struct {
+	Path     string // Dotted path of the property, array items are marked with [].
+	Type     string
+	Required bool
+}
+
+// PayloadFields flattens the payload schema into its properties, sorted by path.
+func (c SensorConfig) PayloadFields() []SchemaField {
+	fields := make([]SchemaField, 0)
+	flattenSchema(c.Schema, "", &fields)
+	slices.SortFunc(fields, func(a, b SchemaField) int { return strings.Compare(a.Path, b.Path) })
+	return fields
+}
+
+// flattenSchema collects the properties of a JSON schema object, including nested objects and array items.
+func flattenSchema(schema map[string]any, prefix string, fields *[]SchemaField) {
+	if items, ok := schema["items"].(map[string]any); ok {
+		flattenSchema(items, prefix+"[]", fields)
+	}
+
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+	required := make(map[string]bool)
+	if reqList, ok := schema["required"].([]any); ok {
+		for _, name := range reqList {
+			if name, ok := name.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	for name, prop := range properties {
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		propSchema, _ := prop.(map[string]any)
+		propType, _ := propSchema["type"].(string)
+		*fields = append(*fields, SchemaField{Path: path, Type: propType, Required: required[name]})
+		flattenSchema(propSchema, path, fields)
+	}
+}
+
+// StreamSensors iterates over all 'sensors' in the tenant.
+func (tc TenantClient) StreamSensors(ctx context.Context) iter.Seq2[Sensor, error] {
 	url, err := tc.urlForTenantEndpoint("orchestration", "sensor/rql", 1)
 	if err != nil {
-		return nil, err
+		return errSeq[Sensor](err)
 	}
 
 	req, err := tc.lc.prepareRequest(ctx, http.MethodPost, url, nil, nil)
 	if err != nil {
-		return nil, err
+		return errSeq[Sensor](err)
 	}
 
-	var results []json.RawMessage
-	if err := tc.lc.rqlRequest(ctx, req, &results, "", "limit(100)"); err != nil {
-		return nil, err
-	}
+	return decodeSeq[Sensor](tc.lc.rqlItems(ctx, req))
+}
 
-	return decodeItems[Sensor](results...)
+// GetSensors gets all 'sensors' in the tenant.
+func (tc TenantClient) GetSensors(ctx context.Context) ([]Sensor, error) {
+	return collect(tc.StreamSensors(ctx))
 }
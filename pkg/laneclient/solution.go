@@ -0,0 +1,90 @@
+package laneclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+)
+
+// SolutionKind selects whether a solution package is exported from a playbook or a component.
+type SolutionKind string
+
+const (
+	SolutionPlaybook  SolutionKind = "solutions"
+	SolutionComponent SolutionKind = "components"
+)
+
+// SolutionImportResult describes the solution created or updated by an import.
+type SolutionImportResult struct {
+	Id      string `json:"id"`
+	Uid     string `json:"uid"`
+	Name    string `json:"name"`
+	Type    string `json:"$type"`
+	Version int    `json:"version"`
+}
+
+// ExportSolution exports a playbook or component with its dependencies as a native solution package (zip archive).
+func (tc TenantClient) ExportSolution(ctx context.Context, kind SolutionKind, solutionId string) ([]byte, error) {
+	url, err := tc.urlForTenantEndpoint("", fmt.Sprintf("solution-builder/%s/%s/export", kind, solutionId), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := tc.lc.prepareRequest(ctx, http.MethodGet, url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/zip, application/octet-stream")
+
+	pkg, err := tc.lc.sendRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export solution %s: %w", solutionId, err)
+	}
+	return pkg, nil
+}
+
+// ImportSolution imports a solution package, existing content with the same Uid is updated.
+// Imports are not idempotent and are never retried.
+func (tc TenantClient) ImportSolution(ctx context.Context, filename string, pkg []byte) (SolutionImportResult, error) {
+	var result SolutionImportResult
+
+	url, err := tc.urlForTenantEndpoint("", "solution-builder/import", 0)
+	if err != nil {
+		return result, err
+	}
+
+	// Wrap the package in a multipart form
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return result, fmt.Errorf("failed to create import form: %w", err)
+	}
+	if _, err := part.Write(pkg); err != nil {
+		return result, fmt.Errorf("failed to write import form: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return result, fmt.Errorf("failed to close import form: %w", err)
+	}
+
+	req, err := tc.lc.prepareRequest(ctx, http.MethodPost, url, nil, body)
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := tc.lc.sendRequest(req)
+	if err != nil {
+		return result, fmt.Errorf("failed to import solution %s: %w", filename, err)
+	}
+
+	if len(resp) > 0 {
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return result, fmt.Errorf("failed to decode import result: %w", err)
+		}
+	}
+	return result, nil
+}
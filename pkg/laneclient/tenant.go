@@ -3,6 +3,7 @@ package laneclient
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -34,3 +35,23 @@ func (lc LaneClient) GetTenants(ctx context.Context) (TenantResponse, error) {
 	}
 	return decodeItem[TenantResponse](resp)
 }
+
+// TenantSettings holds the settings of a tenant, only the version is modelled.
+type TenantSettings struct {
+	ApiVersion string `json:"apiVersion"` // Turbine version, optionally followed by +build metadata.
+}
+
+// GetProductVersion gets the Turbine version of the tenant, without build metadata.
+func (tc TenantClient) GetProductVersion(ctx context.Context) (string, error) {
+	url, err := tc.urlForTenantEndpoint("", "settings", 0)
+	if err != nil {
+		return "", err
+	}
+
+	settings, err := getResource[TenantSettings](ctx, tc.lc, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tenant settings: %w", err)
+	}
+	version, _, _ := strings.Cut(settings.ApiVersion, "+")
+	return version, nil
+}
@@ -0,0 +1,94 @@
+package laneclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// transportConfig holds the settings used to construct the HTTP transport of a LaneClient.
+type transportConfig struct {
+	custom             http.RoundTripper
+	proxy              *url.URL
+	rootCAs            *x509.CertPool
+	insecureSkipVerify bool
+}
+
+// build returns the configured round tripper, or builds one from the default transport.
+// A custom round tripper takes precedence over the proxy and TLS settings.
+func (tc transportConfig) build() http.RoundTripper {
+	if tc.custom != nil {
+		return tc.custom
+	}
+	if tc.proxy == nil && tc.rootCAs == nil && !tc.insecureSkipVerify {
+		return http.DefaultTransport
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tc.proxy != nil {
+		transport.Proxy = http.ProxyURL(tc.proxy)
+	}
+	if tc.rootCAs != nil || tc.insecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:            tc.rootCAs,
+			InsecureSkipVerify: tc.insecureSkipVerify, //nolint:gosec // explicit opt-in for self-signed on-prem deployments
+		}
+	}
+	return transport
+}
+
+// WithBaseURL overrides the base URL of the API (e.g. https://swimlane.example.com for an on-prem deployment).
+func WithBaseURL(baseURL string) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithRoundTripper sets a custom transport for all requests, the proxy and TLS options are ignored when this is used.
+func WithRoundTripper(rt http.RoundTripper) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.transport.custom = rt
+	}
+}
+
+// WithProxy routes all requests through the given proxy instead of the proxy from the environment.
+func WithProxy(proxy *url.URL) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.transport.proxy = proxy
+	}
+}
+
+// WithRootCAs sets the certificate pool used to verify the server certificate.
+func WithRootCAs(pool *x509.CertPool) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.transport.rootCAs = pool
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the server certificate, only use this for testing.
+func WithInsecureSkipVerify(skip bool) func(*LaneClient) {
+	return func(lc *LaneClient) {
+		lc.transport.insecureSkipVerify = skip
+	}
+}
+
+// LoadCABundle reads a PEM encoded CA bundle from disk and appends it to the system certificate pool.
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", path, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
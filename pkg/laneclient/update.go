@@ -0,0 +1,79 @@
+package laneclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrConflict is returned when a resource was modified by someone else between reading and updating it.
+var ErrConflict = errors.New("the resource was modified since it was read, reload it and try again")
+
+// updateResource performs a read-modify-write cycle on a JSON resource. The resource is updated as a generic document
+// so fields that are not part of the response models are written back unmodified.
+// The update is conditional: the ETag of the read is sent as If-Match and the version of the document is written back as read,
+// so the server rejects the update if the resource changed in between. Rejected updates return ErrConflict and are not retried.
+func (lc LaneClient) updateResource(ctx context.Context, url string, mutate func(doc map[string]any) error) error {
+	req, err := lc.prepareRequest(ctx, http.MethodGet, url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, header, err := lc.sendRequestWithHeader(req)
+	if err != nil {
+		return fmt.Errorf("failed to get resource: %w", err)
+	}
+
+	doc := make(map[string]any)
+	if err := json.Unmarshal(resp, &doc); err != nil {
+		return fmt.Errorf("failed to decode resource: %w", err)
+	}
+	version, hasVersion := doc["version"]
+
+	if err := mutate(doc); err != nil {
+		return err
+	}
+	if hasVersion {
+		doc["version"] = version
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode resource: %w", err)
+	}
+
+	req, err = lc.prepareRequest(ctx, http.MethodPut, url, nil, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if etag := header.Get("ETag"); etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+
+	if _, err := lc.sendRequest(req); err != nil {
+		if IsConflict(err) {
+			return fmt.Errorf("failed to update resource: %w: %w", ErrConflict, err)
+		}
+		return fmt.Errorf("failed to update resource: %w", err)
+	}
+	return nil
+}
+
+// getResource requests a single resource and decodes it.
+func getResource[T ResponseModel](ctx context.Context, lc LaneClient, url string) (T, error) {
+	var empty T
+	req, err := lc.prepareRequest(ctx, http.MethodGet, url, nil, nil)
+	if err != nil {
+		return empty, err
+	}
+
+	resp, err := lc.sendRequest(req)
+	if err != nil {
+		return empty, err
+	}
+
+	return decodeItem[T](resp)
+}
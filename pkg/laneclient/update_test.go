@@ -0,0 +1,91 @@
+package laneclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+const taskPath = "/api/account/acc/tenant/t1/orchestrationtask/task1"
+
+// taskServer serves an orchestration task, PUT requests are passed to the put handler.
+func taskServer(t *testing.T, etag string, put http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != taskPath {
+			t.Errorf("unexpected path %s, want %s", r.URL.Path, taskPath)
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if etag != "" {
+				w.Header().Set("ETag", etag)
+			}
+			io.WriteString(w, `{"id":"task1","name":"Task","disabled":false,"version":7,"custom":{"kept":true}}`) //nolint:errcheck
+		case http.MethodPut:
+			put(w, r)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+}
+
+func TestUpdateResource(t *testing.T) {
+	var ifMatch string
+	var body map[string]any
+	srv := taskServer(t, `"abc"`, func(w http.ResponseWriter, r *http.Request) {
+		ifMatch = r.Header.Get("If-Match")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode PUT body: %v", err)
+		}
+		io.WriteString(w, `{}`) //nolint:errcheck
+	})
+	defer srv.Close()
+
+	tc := NewTenantClient(newTestClient(WithBaseURL(srv.URL)), Tenant{Id: "t1"})
+	if err := tc.SetOrchestrationTaskDisabled(context.Background(), "task1", true); err != nil {
+		t.Fatalf("SetOrchestrationTaskDisabled failed: %v", err)
+	}
+
+	if ifMatch != `"abc"` {
+		t.Errorf("If-Match = %q, want %q", ifMatch, `"abc"`)
+	}
+	if body["disabled"] != true {
+		t.Errorf("disabled = %v, want true", body["disabled"])
+	}
+	// The version is the precondition of the update and members that are not modelled must survive
+	if body["version"] != float64(7) {
+		t.Errorf("version = %v, want 7", body["version"])
+	}
+	if custom, ok := body["custom"].(map[string]any); !ok || custom["kept"] != true {
+		t.Errorf("unmodelled member lost: %v", body["custom"])
+	}
+}
+
+func TestUpdateResourceConflict(t *testing.T) {
+	for _, status := range []int{http.StatusConflict, http.StatusPreconditionFailed} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var puts atomic.Int32
+			srv := taskServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+				puts.Add(1)
+				http.Error(w, `{"message":"version mismatch"}`, status)
+			})
+			defer srv.Close()
+
+			// Conflicts must not be retried, a retry would replay the stale document
+			lc := newTestClient(WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxRetries: 3}))
+			err := NewTenantClient(lc, Tenant{Id: "t1"}).SetOrchestrationTaskDisabled(context.Background(), "task1", true)
+			if !errors.Is(err, ErrConflict) || !IsConflict(err) {
+				t.Fatalf("expected a conflict error, got %v", err)
+			}
+			if puts.Load() != 1 {
+				t.Errorf("PUT requests = %d, want 1", puts.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package laneclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Workspaces []Workspace
+
+// Workspace groups applications and dashboards.
+type Workspace struct {
+	Id           string    `json:"id"`
+	Uid          string    `json:"uid"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Applications []string  `json:"applications"` // Ids of the applications in the workspace.
+	Dashboards   []string  `json:"dashboards"`   // Ids of the dashboards in the workspace.
+	CreatedDate  time.Time `json:"createdDate"`
+	ModifiedDate time.Time `json:"modifiedDate"`
+}
+
+type Dashboards []Dashboard
+
+// Dashboard is a collection of report widgets.
+type Dashboard struct {
+	Id           string          `json:"id"`
+	Uid          string          `json:"uid"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Workspaces   []string        `json:"workspaces"`
+	Items        []DashboardItem `json:"items"`
+	CreatedDate  time.Time       `json:"createdDate"`
+	ModifiedDate time.Time       `json:"modifiedDate"`
+}
+
+// DashboardItem is a single widget on a dashboard.
+type DashboardItem struct {
+	Id       string `json:"id"`
+	Type     string `json:"$type"`
+	ReportId string `json:"reportId"`
+	Title    string `json:"title"`
+}
+
+type Reports []Report
+
+// Report is a saved search over the records of one or more applications.
+type Report struct {
+	Id             string         `json:"id"`
+	Uid            string         `json:"uid"`
+	Name           string         `json:"name"`
+	ApplicationIds []string       `json:"applicationIds"`
+	Columns        []string       `json:"columns"` // Ids of the fields shown in the report.
+	Filters        []ReportFilter `json:"filters"`
+	Sorts          map[string]any `json:"sorts"`
+	ChartOptions   map[string]any `json:"chartOptions"`
+	CreatedDate    time.Time      `json:"createdDate"`
+	ModifiedDate   time.Time      `json:"modifiedDate"`
+}
+
+// ReportFilter describes a filter condition on a report field.
+type ReportFilter struct {
+	FieldId    string `json:"fieldId"`
+	FilterType string `json:"filterType"`
+	Value      any    `json:"value"`
+}
+
+// FieldIds returns the ids of all fields used by the report (columns and filters).
+func (r Report) FieldIds() map[string]bool {
+	fieldIds := make(map[string]bool, len(r.Columns)+len(r.Filters))
+	for _, col := range r.Columns {
+		fieldIds[col] = true
+	}
+	for _, filter := range r.Filters {
+		if filter.FieldId != "" {
+			fieldIds[filter.FieldId] = true
+		}
+	}
+	return fieldIds
+}
+
+// getTenantList gets a resource that is returned as a single JSON list.
+func getTenantList[T Workspaces | Dashboards | Reports | LegacyTasks | LegacyTriggers](ctx context.Context, tc TenantClient, endpoint string) (T, error) {
+	url, err := tc.urlForTenantEndpoint("", endpoint, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := tc.lc.prepareRequest(ctx, http.MethodGet, url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tc.lc.sendRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", endpoint, err)
+	}
+
+	return decodeItem[T](resp)
+}
+
+// GetWorkspaces gets all workspaces in the tenant.
+func (tc TenantClient) GetWorkspaces(ctx context.Context) ([]Workspace, error) {
+	return getTenantList[Workspaces](ctx, tc, "workspaces")
+}
+
+// GetDashboards gets all dashboards in the tenant.
+func (tc TenantClient) GetDashboards(ctx context.Context) ([]Dashboard, error) {
+	return getTenantList[Dashboards](ctx, tc, "dashboard")
+}
+
+// GetReports gets all saved reports in the tenant.
+func (tc TenantClient) GetReports(ctx context.Context) ([]Report, error) {
+	return getTenantList[Reports](ctx, tc, "reports")
+}